@@ -0,0 +1,117 @@
+package main
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"github.com/jharlan-hash/gospell/internal/dictionary"
+)
+
+// isArchive reports whether source names a WordNet release archive, by URL or by file name.
+func isArchive(source string) bool {
+	return strings.HasPrefix(source, "http://") || strings.HasPrefix(source, "https://") ||
+		strings.HasSuffix(source, ".tar.gz") || strings.HasSuffix(source, ".tgz")
+}
+
+// readArchive reads the WordNet database in the release archive at source, a URL or a .tar.gz file.
+func readArchive(source string) (dictionary.Dictionary, error) {
+	var r io.Reader
+	if strings.HasPrefix(source, "http://") || strings.HasPrefix(source, "https://") {
+		resp, err := http.Get(source)
+		if err != nil {
+			return nil, err
+		}
+		defer resp.Body.Close()
+		if resp.StatusCode != http.StatusOK {
+			return nil, fmt.Errorf("%s: %s", source, resp.Status)
+		}
+		r = resp.Body
+	} else {
+		f, err := os.Open(source)
+		if err != nil {
+			return nil, err
+		}
+		defer f.Close()
+		r = f
+	}
+
+	dir, err := os.MkdirTemp("", "gospell-wordnet-")
+	if err != nil {
+		return nil, err
+	}
+	defer os.RemoveAll(dir)
+
+	if err := extractWordNet(r, dir); err != nil {
+		return nil, fmt.Errorf("%s: %w", source, err)
+	}
+	fsys := os.DirFS(dir)
+	if !dictionary.IsWordNet(fsys) {
+		return nil, fmt.Errorf("%s: no WordNet database in the archive", source)
+	}
+	d, err := dictionary.ReadWordNet(fsys)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", source, err)
+	}
+	return d, nil
+}
+
+// extractWordNet writes the index.* and data.* files of the gzipped tar archive r to dir,
+// wherever they are in the archive, e.g. under dict/.
+func extractWordNet(r io.Reader, dir string) error {
+	gz, err := gzip.NewReader(r)
+	if err != nil {
+		return err
+	}
+	defer gz.Close()
+
+	tr := tar.NewReader(gz)
+	for {
+		h, err := tr.Next()
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		name := path.Base(h.Name)
+		if h.Typeflag != tar.TypeReg || !isWordNetFile(name) {
+			continue
+		}
+		if err := writeFile(filepath.Join(dir, name), tr); err != nil {
+			return err
+		}
+	}
+}
+
+// isWordNetFile reports whether name is one of the database files ReadWordNet reads.
+func isWordNetFile(name string) bool {
+	kind, pos, ok := strings.Cut(name, ".")
+	if !ok || (kind != "index" && kind != "data") {
+		return false
+	}
+	switch pos {
+	case "noun", "verb", "adj", "adv":
+		return true
+	}
+	return false
+}
+
+func writeFile(name string, r io.Reader) error {
+	f, err := os.Create(name)
+	if err != nil {
+		return err
+	}
+	if _, err := io.Copy(f, r); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
@@ -0,0 +1,73 @@
+package main
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// wordNetArchive returns a gzipped tar archive laid out like a WordNet release, with a tiny database under dict/.
+func wordNetArchive(t *testing.T) []byte {
+	t.Helper()
+	files := map[string]string{
+		"WordNet-3.0/README":          "not a database file\n",
+		"WordNet-3.0/dict/index.noun": "cat n 1 1 @ 1 0 02121620\n",
+		"WordNet-3.0/dict/data.noun":  "02121620 05 n 01 cat 0 | feline mammal usually having thick soft fur\n",
+		"WordNet-3.0/dict/index.verb": "",
+		"WordNet-3.0/dict/data.verb":  "",
+		"WordNet-3.0/dict/index.adj":  "",
+		"WordNet-3.0/dict/data.adj":   "",
+		"WordNet-3.0/dict/index.adv":  "",
+		"WordNet-3.0/dict/data.adv":   "",
+	}
+
+	var buf bytes.Buffer
+	gz := gzip.NewWriter(&buf)
+	tw := tar.NewWriter(gz)
+	for name, data := range files {
+		if err := tw.WriteHeader(&tar.Header{Name: name, Mode: 0o644, Size: int64(len(data)), Typeflag: tar.TypeReg}); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := tw.Write([]byte(data)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := tw.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if err := gz.Close(); err != nil {
+		t.Fatal(err)
+	}
+	return buf.Bytes()
+}
+
+func TestReadArchive(t *testing.T) {
+	archive := wordNetArchive(t)
+
+	path := filepath.Join(t.TempDir(), "WNdb-3.0.tar.gz")
+	if err := os.WriteFile(path, archive, 0o644); err != nil {
+		t.Fatal(err)
+	}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write(archive)
+	}))
+	defer server.Close()
+
+	for _, source := range []string{path, server.URL + "/WNdb-3.0.tar.gz"} {
+		if !isArchive(source) {
+			t.Fatalf("isArchive(%q) = false, want true", source)
+		}
+		d, err := readSource(source)
+		if err != nil {
+			t.Fatalf("readSource(%q) error = %v", source, err)
+		}
+		if got := d["cat"]; len(got) != 1 || got[0].Definition != "feline mammal usually having thick soft fur" {
+			t.Errorf("readSource(%q) defines cat as %+v, want the WordNet definition", source, got)
+		}
+	}
+}
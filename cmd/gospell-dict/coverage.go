@@ -0,0 +1,59 @@
+package main
+
+import (
+	"strings"
+
+	"github.com/jharlan-hash/gospell/internal/api"
+	"github.com/jharlan-hash/gospell/internal/dictionary"
+)
+
+// wordlist is the word list the dictionary's coverage is measured against.
+type wordlist struct {
+	name string
+	list []string
+	set  map[string]bool
+}
+
+// readWordlist reads the plain text word list at path, or the embedded list if path is empty.
+func readWordlist(path string) (*wordlist, error) {
+	w := &wordlist{name: path}
+	if path == "" {
+		w.name = "the embedded word list"
+		w.list = api.Embedded().Words()
+	} else {
+		list, err := api.FromFile(path)
+		if err != nil {
+			return nil, err
+		}
+		w.list = list.Words()
+	}
+
+	w.set = make(map[string]bool, len(w.list))
+	for _, word := range w.list {
+		w.set[strings.ToLower(word)] = true
+	}
+	return w, nil
+}
+
+func (w *wordlist) has(word string) bool {
+	return w.set[word]
+}
+
+// undefined returns the words of the list that d doesn't define, in list order.
+func (w *wordlist) undefined(d dictionary.Dictionary) []string {
+	var words []string
+	for _, word := range w.list {
+		if len(d[strings.ToLower(word)]) == 0 {
+			words = append(words, word)
+		}
+	}
+	return words
+}
+
+// percent returns n as a percentage of total, or 0 if total is 0.
+func percent(n, total int) float64 {
+	if total == 0 {
+		return 0
+	}
+	return 100 * float64(n) / float64(total)
+}
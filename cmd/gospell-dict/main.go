@@ -0,0 +1,178 @@
+// Command gospell-dict builds the dictionary gospell embeds, internal/definition/wordmap.gob,
+// from WordNet or Free Dictionary API data, and reports how much of a word list it defines.
+//
+// Usage:
+//
+//	gospell-dict [-o wordmap.gob] [--wordlist FILE] [--missing FILE] [--trim] SOURCE...
+//
+// Each SOURCE is a WordNet database directory (the dict directory of a WordNet 3.x release),
+// a WordNet release archive (a .tar.gz file or its URL, e.g. https://wordnetcode.princeton.edu/3.0/WNdb-3.0.tar.gz),
+// a Free Dictionary API dump (a JSON file of API responses, or a directory of them),
+// or "-" to read a dump from stdin. When sources define the same word, the first one wins.
+//
+// For example, to rebuild the dictionary from WordNet, with Free Dictionary definitions for the words it lacks:
+//
+//	go run ./cmd/gospell-dict -o internal/definition/wordmap.gob ~/WordNet-3.0/dict freedict/
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/jharlan-hash/gospell/internal/dictionary"
+	"github.com/pborman/getopt"
+)
+
+func main() {
+	output, wordlist, missing := "wordmap.gob", "", ""
+	var trim, help bool
+	getopt.SetParameters("SOURCE...")
+	getopt.StringVarLong(&output, "output", 'o', "where to write the dictionary")
+	getopt.StringVarLong(&wordlist, "wordlist", 'w', "word list to report coverage against (default the embedded list)")
+	getopt.StringVarLong(&missing, "missing", 0, "write the words of the word list without a definition to this file")
+	getopt.BoolVarLong(&trim, "trim", 0, "leave out words that aren't in the word list, for a smaller dictionary")
+	getopt.BoolVarLong(&help, "help", 'h', "display help")
+	getopt.Parse()
+
+	if help {
+		getopt.Usage()
+		return
+	}
+	if getopt.NArgs() == 0 {
+		getopt.Usage()
+		os.Exit(2)
+	}
+
+	words, err := readWordlist(wordlist)
+	if err != nil {
+		fatal(err)
+	}
+
+	dict := make(dictionary.Dictionary)
+	for _, source := range getopt.Args() {
+		d, err := readSource(source)
+		if err != nil {
+			fatal(err)
+		}
+		added := dict.Merge(d)
+		fmt.Printf("%s: %d words, %d not defined by earlier sources\n", source, len(d), added)
+	}
+	if trim {
+		for word := range dict {
+			if !words.has(word) {
+				delete(dict, word)
+			}
+		}
+	}
+
+	if err := write(output, dict); err != nil {
+		fatal(err)
+	}
+	fmt.Printf("%s: %d words\n", output, len(dict))
+
+	undefined := words.undefined(dict)
+	defined := len(words.list) - len(undefined)
+	fmt.Printf("coverage of %s: %d of %d words (%.1f%%)", words.name, defined, len(words.list), percent(defined, len(words.list)))
+	if len(undefined) > 0 {
+		fmt.Printf(", %d undefined, e.g. %s", len(undefined), strings.Join(undefined[:min(len(undefined), 5)], ", "))
+	}
+	fmt.Println()
+
+	if missing != "" {
+		if err := os.WriteFile(missing, []byte(strings.Join(undefined, "\n")+"\n"), 0o644); err != nil {
+			fatal(err)
+		}
+	}
+}
+
+// readSource reads a dictionary from source, see the package documentation.
+func readSource(source string) (dictionary.Dictionary, error) {
+	if source == "-" {
+		return dictionary.ReadFreeDictionary(bufio.NewReader(os.Stdin))
+	}
+	if isArchive(source) {
+		return readArchive(source)
+	}
+
+	info, err := os.Stat(source)
+	if err != nil {
+		return nil, err
+	}
+	if !info.IsDir() {
+		return readDump(source)
+	}
+
+	fsys := os.DirFS(source)
+	if dictionary.IsWordNet(fsys) {
+		d, err := dictionary.ReadWordNet(fsys)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", source, err)
+		}
+		return d, nil
+	}
+
+	// a directory of saved API responses, e.g. one file per word
+	d := make(dictionary.Dictionary)
+	err = filepath.WalkDir(source, func(path string, e fs.DirEntry, err error) error {
+		if err != nil || e.IsDir() || filepath.Ext(path) != ".json" {
+			return err
+		}
+		dump, err := readDump(path)
+		if err != nil {
+			return err
+		}
+		d.Merge(dump)
+		return nil
+	})
+	return d, err
+}
+
+// readDump reads a file of Free Dictionary API responses.
+func readDump(path string) (dictionary.Dictionary, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	d, err := dictionary.ReadFreeDictionary(bufio.NewReader(f))
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	return d, nil
+}
+
+// write writes the dictionary to path, replacing it only once the whole dictionary is written.
+func write(path string, d dictionary.Dictionary) error {
+	tmp, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name()) // fails harmlessly once renamed
+
+	w := bufio.NewWriter(tmp)
+	if err := d.Encode(w); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := w.Flush(); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	if err := os.Chmod(tmp.Name(), 0o644); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), path)
+}
+
+func fatal(err error) {
+	fmt.Fprintln(os.Stderr, "gospell-dict:", err)
+	os.Exit(1)
+}
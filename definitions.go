@@ -0,0 +1,125 @@
+package main
+
+import (
+	"cmp"
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/jharlan-hash/gospell/internal/config"
+	"github.com/jharlan-hash/gospell/internal/definition"
+	"github.com/muesli/reflow/wordwrap"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// noDefinition is shown in place of the definition of a word that has none.
+const noDefinition = "No definition available."
+
+// noSentence is shown when the user asks for a sentence using a word that has none.
+const noSentence = "No example sentence available."
+
+// maxSkips bounds how many words in a row definition.Skip mode passes over,
+// so that a word list with hardly any definitions can still be practiced.
+const maxSkips = 20
+
+// definitionProvider returns where definitions are looked up, in priority order:
+// the user's definitions file, the embedded dictionary and, if the user opts in with --online
+// or --missing-definitions=fallback, a dictionaryapi.dev-compatible API whose answers are cached on disk.
+// The API is only asked about the words neither of the others defines.
+func definitionProvider(cfg *config.Config, dictionary definition.Dictionary) (definition.Provider, error) {
+	path := cfg.DefinitionsFile
+	if path != "" {
+		// a missing file is only an error if the user asked for it by name
+		if _, err := os.Stat(path); err != nil {
+			return nil, err
+		}
+	} else {
+		path, _ = definition.DefaultOverlayPath()
+	}
+
+	chain := definition.Chain{dictionary}
+	if path != "" {
+		overlay, err := definition.LoadOverlay(path)
+		if err != nil {
+			return nil, err
+		}
+		chain = definition.Chain{overlay, dictionary}
+	}
+	missing, _ := definition.ParseMissing(cfg.MissingDefinitions) // checked by cfg.Validate
+	if !cfg.Online && missing != definition.Fallback {
+		return chain, nil // words are only sent to a third party if the user asks for it
+	}
+
+	var remote definition.Provider = &definition.Client{BaseURL: cmp.Or(cfg.DefinitionsURL, definition.DefaultBaseURL)}
+	if !cfg.NoCache {
+		// without a cache dir we simply look words up every time
+		if dir, err := definition.DefaultCacheDir(); err == nil {
+			remote = &definition.Cached{Provider: remote, Dir: dir}
+		}
+	}
+	return append(chain, remote), nil
+}
+
+// skipsUndefined reports whether the word of msg is passed over for having no definitions,
+// as in definition.Skip mode. Words whose lookup failed are shown rather than skipped.
+func (m *model) skipsUndefined(msg wordMessage) bool {
+	return m.missing == definition.Skip && errors.Is(msg.result.Err, definition.ErrNotFound) && m.skipped < maxSkips
+}
+
+// missingDefinition returns what to show in place of the definition of word, which has none because of err:
+// its example sentence with the word and its forms blanked out in definition.Sentence mode, or that none is available.
+func (m *model) missingDefinition(word string, err error) string {
+	if !errors.Is(err, definition.ErrNotFound) {
+		return fmt.Sprintf("%s (%v)", noDefinition, err)
+	}
+	if m.missing == definition.Sentence {
+		if sentence := m.maskedSentence(word); sentence != "" {
+			return fmt.Sprintf("Used in a sentence: %q", sentence)
+		}
+	}
+	return noDefinition
+}
+
+// maskedSentence returns the word list's example sentence for word with the word and its forms blanked out,
+// or "" if there is none or it doesn't use the word in any form.
+func (m *model) maskedSentence(word string) string {
+	sentence := m.sentences[word]
+	masked := definition.Mask(sentence, word)
+	if masked == sentence {
+		return ""
+	}
+	return masked
+}
+
+// withRevealed adds to correction the definition of the word the user just answered as it reads unmasked,
+// if masking blanked anything out of the one shown, since the next word's definition takes its place right away.
+func (m *model) withRevealed(correction string) string {
+	revealed := wordwrap.String(m.definitionState.Reveal(), 100)
+	if revealed == "" || revealed == m.definition {
+		return correction
+	}
+	if correction == "" {
+		return revealed
+	}
+	return correction + "\n" + revealed
+}
+
+// showSentence shows a sentence using the current word, with the word and its forms blanked out:
+// the word list's example sentence, or else one from the dictionary.
+// With speakSentences set it returns a command that reads the sentence aloud.
+func (m *model) showSentence() tea.Cmd {
+	sentence := cmp.Or(m.sentences[m.word], m.definitionState.Sentence())
+	if sentence == "" {
+		m.sentence = noSentence
+		return nil
+	}
+	m.sentence = fmt.Sprintf("%q", definition.Mask(sentence, m.word))
+
+	if !m.speakSentences || m.textOnly {
+		return nil
+	}
+	return func() tea.Msg {
+		return speechMessage{err: m.ttsState.SaySentence(sentence)}
+	}
+}
@@ -0,0 +1,95 @@
+package main
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+
+	"github.com/jharlan-hash/gospell/internal/config"
+
+	"github.com/jharlan-hash/gospell/internal/definition"
+	"github.com/jharlan-hash/gospell/internal/homophone"
+)
+
+func TestModel_missingDefinition(t *testing.T) {
+	tests := []struct {
+		name     string // description of this test case
+		sentence string
+		want     string
+	}{
+		{"TestWord", "We hop over the fence.", `Used in a sentence: "We ____ over the fence."`},
+		{"TestInflection", "The rabbit hopped away.", `Used in a sentence: "The rabbit ____ away."`},
+		{"TestWithoutTheWord", "The rabbit ran away.", noDefinition},
+		{"TestNoSentence", "", noDefinition},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := &model{missing: definition.Sentence, sentences: map[string]string{"hop": tt.sentence}}
+			if got := m.missingDefinition("hop", definition.ErrNotFound); got != tt.want {
+				t.Errorf("missingDefinition() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestModel_homophonePrompt(t *testing.T) {
+	m := &model{
+		homophones:    homophone.Parse("pair pare pear"),
+		homophoneMode: homophone.Define,
+		sentences:     map[string]string{"pare": "She pared the apples."},
+	}
+	if got, want := m.homophonePrompt("pare"), `Sounds like another word. Spell the word in: "She ____ the apples."`; got != want {
+		t.Errorf("homophonePrompt() = %q, want %q", got, want)
+	}
+	if got, want := m.homophonePrompt("pear"), "Sounds like another word. Spell the one with this meaning:"; got != want {
+		t.Errorf("homophonePrompt() = %q, want %q", got, want)
+	}
+}
+
+func TestDefinitionProvider_Fallback(t *testing.T) {
+	var requests atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		w.Write([]byte(`[{"word":"syzygy","meanings":[{"partOfSpeech":"noun","definitions":[{"definition":"an alignment of three celestial bodies"}]}]}]`))
+	}))
+	defer srv.Close()
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir()) // no definitions file of the user's
+
+	d := definition.Dictionary{}
+	d.Add("cat", "noun", "a small feline")
+
+	tests := []struct {
+		name     string // description of this test case
+		missing  definition.Missing
+		wantSent int32 // requests made for syzygy
+	}{
+		{"TestFallback", definition.Fallback, 1},
+		{"TestShowStaysOffline", definition.Show, 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			requests.Store(0)
+			cfg := config.Default()
+			cfg.MissingDefinitions = string(tt.missing)
+			cfg.DefinitionsURL = srv.URL
+			cfg.NoCache = true
+			p, err := definitionProvider(&cfg, d)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if _, err := p.Lookup(context.Background(), "cat"); err != nil || requests.Load() != 0 {
+				t.Errorf("looking up cat = %v after %d requests, want the dictionary's definition", err, requests.Load())
+			}
+			entries, err := p.Lookup(context.Background(), "syzygy")
+			if requests.Load() != tt.wantSent {
+				t.Errorf("made %d requests for syzygy, want %d", requests.Load(), tt.wantSent)
+			}
+			if found := err == nil && len(entries) > 0; found != (tt.wantSent > 0) {
+				t.Errorf("looking up syzygy = %+v, %v", entries, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,46 @@
+package main
+
+import (
+	"errors"
+
+	"github.com/jharlan-hash/gospell/internal/api"
+	"github.com/jharlan-hash/gospell/internal/definition"
+	"github.com/jharlan-hash/gospell/internal/morphology"
+)
+
+// newDrill groups the words of lists into families and returns a drill of them.
+// Families are picked by their lemma from the source pick makes of the lemmas,
+// so the difficulty, frequency and definition filters apply to the lemma of each family.
+// Grouping the unfiltered words keeps the forms a dictionary of lemmas doesn't list.
+func newDrill(lists []*api.List, dictionary definition.Dictionary, pick func(lemmas *api.List) api.WordSource) (*morphology.Drill, error) {
+	var words []string
+	for _, list := range lists {
+		words = append(words, list.Words()...)
+	}
+
+	families := morphology.Group(words, dictionary)
+	lemmas := pick(api.NewList(morphology.Lemmas(families)))
+	if lemmas.Len() == 0 {
+		return nil, errors.New("no word families to drill: the word lists need words like hope, hoped and hoping")
+	}
+	return morphology.NewDrill(families, lemmas), nil
+}
+
+// drillPrompt returns what the user is asked to spell word as, e.g. "Spell the past tense of hop",
+// or "" outside of drills and for the first word of a family.
+func (m *model) drillPrompt(word string) string {
+	if m.drill == nil {
+		return ""
+	}
+	form, _ := m.drill.Form(word)
+	return form.Prompt()
+}
+
+// drillHint returns the spelling rule of word, e.g. "double the p before -ed", or "" if there is none to learn.
+func (m *model) drillHint(word string) string {
+	if m.drill == nil {
+		return ""
+	}
+	form, _ := m.drill.Form(word)
+	return form.Hint()
+}
@@ -1,54 +1,231 @@
 package main
 
 import (
+	"cmp"
 	"context"
+	"errors"
 	"fmt"
 	"log"
 	"os"
+	"text/tabwriter"
+	"text/template"
 	"time"
 
 	"github.com/jharlan-hash/gospell/internal/api"
+	"github.com/jharlan-hash/gospell/internal/audio"
+	"github.com/jharlan-hash/gospell/internal/audio/speaker"
 	"github.com/jharlan-hash/gospell/internal/definition"
+	"github.com/jharlan-hash/gospell/internal/homophone"
+	"github.com/jharlan-hash/gospell/internal/morphology"
+	"github.com/jharlan-hash/gospell/internal/review"
 	"github.com/jharlan-hash/gospell/internal/tts"
 	"github.com/jharlan-hash/gospell/internal/wpm"
 	"github.com/muesli/reflow/wordwrap"
 
-	texttospeech "cloud.google.com/go/texttospeech/apiv1"
 	"github.com/charmbracelet/bubbles/textinput"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
-	"github.com/pborman/getopt"
 	"google.golang.org/api/option"
 )
 
 func main() {
-	credentialFlag := getopt.StringLong("credentials", 'c', "", "Path to Google Cloud credentials JSON file (optional)")
-	helpFlag := getopt.BoolLong("help", 'h', "display help")
-
-	getopt.Parse()
-
-	if *helpFlag {
-		getopt.Usage()
-		os.Exit(0)
+	if len(os.Args) > 1 {
+		switch os.Args[1] {
+		case "lists":
+			os.Exit(listsCommand(os.Args[2:]))
+		case "packs":
+			os.Exit(packsCommand(os.Args[2:]))
+		}
 	}
 
+	opts := parseOptions()
+
 	ctx, cancel := context.WithCancel(context.Background())
 	defer cancel()
 
-	model := initialModel(*credentialFlag, ctx)
+	var synthesizer tts.Synthesizer = tts.Silent{}
+	if !opts.Silent || opts.listVoices {
+		google, err := newGoogle(ctx, opts.Credentials, opts.Endpoint)
+		if err != nil {
+			log.Fatal(err)
+		}
+		if google != nil {
+			defer google.Close()
+			synthesizer = google
+		}
+	}
+
+	var speechOff string // why words won't be heard, if they won't
+	if _, silent := synthesizer.(tts.Silent); silent && !opts.Silent {
+		speechOff = "no --credentials or --endpoint given"
+	}
+
+	if opts.listVoices {
+		listVoices(ctx, synthesizer, opts.Voice.LanguageCode)
+		return
+	}
+
+	dictionary := definition.LoadCache()
+
+	lists, err := loadWordlists(&opts.Config, dictionary)
+	if err != nil {
+		log.Fatal(err)
+	}
+	overrideDefinitions(dictionary, lists.Entries)
+	definitions, err := definitionProvider(&opts.Config, dictionary)
+	if err != nil {
+		log.Fatal(err)
+	}
+	var reviews *review.Source
+	if !opts.NoReview {
+		if reviews, err = mixReviews(opts.ReviewFile, lists, opts.ReviewRatio); err != nil {
+			log.Fatal(err)
+		}
+	}
+	words := lists.Words
+	if opts.Seed != nil {
+		api.Seed(words, *opts.Seed)
+	}
+
+	ttsState := &tts.TTS{}
+	ttsState.Synthesizer = synthesizer
+	ttsState.Player = &audio.Discard{}
+	ttsState.Voice = opts.Voice
+	ttsState.Ctx = ctx
+	ttsState.Recordings = lists.Recordings
+
+	if _, silent := synthesizer.(tts.Silent); !silent {
+		if player, err := speaker.New(); err == nil {
+			defer player.Close()
+			ttsState.Player = player
+		} else {
+			speechOff = "no sound card: " + err.Error()
+		}
+	}
+
+	if !opts.NoCache {
+		// without a cache dir we simply run uncached
+		if dir, err := tts.DefaultCacheDir(); err == nil {
+			ttsState.Cache = &tts.Cache{Dir: dir}
+		}
+	}
+
+	if opts.Bee {
+		tmpl, err := beeTemplate(opts.BeeTemplateFile)
+		if err != nil {
+			log.Fatal(err)
+		}
+		ttsState.Template = tmpl
+		ttsState.Describe = describeWord(definitions, sentences(lists.Entries))
+	}
 
-	p := tea.NewProgram(&model, tea.WithAltScreen())
+	model := initialModel(ttsState, definitions, words, opts.Prefetch)
+	model.reviews = reviews
+	model.drill = lists.Drill
+	model.homophones = homophone.Embedded()
+	model.homophoneMode, _ = homophone.ParseMode(opts.Homophones) // checked by opts.Validate
+	model.sentences = sentences(lists.Entries)
+	model.missing, _ = definition.ParseMissing(opts.MissingDefinitions) // checked by opts.Validate
+	model.definitionState.Masked = !opts.NoMask
+	model.speakSentences = opts.SpeakSentences
+	if speechOff != "" {
+		model.disableSpeech(speechOff)
+	}
+
+	programOpts := []tea.ProgramOption{tea.WithAltScreen()}
+	if readsStdin(opts.Wordlists) {
+		// the word list used up stdin, so read keys from the terminal instead
+		programOpts = append(programOpts, tea.WithInputTTY())
+	}
+	p := tea.NewProgram(&model, programOpts...)
 	if _, err := p.Run(); err != nil {
 		log.Fatal(err)
 	}
+	if err := model.saveReviews(); err != nil {
+		log.Fatal("Couldn't save reviews: ", err)
+	}
+}
+
+// newGoogle connects to the Google Cloud TextToSpeech API, or to a stand-in at endpoint.
+// It returns nil if neither credentials nor an endpoint are given.
+func newGoogle(ctx context.Context, credentialPath, endpoint string) (*tts.Google, error) {
+	switch {
+	case credentialPath != "":
+		// User provided custom credentials file
+		var opts []option.ClientOption
+		if endpoint != "" {
+			opts = append(opts, option.WithEndpoint(endpoint))
+		}
+		google, err := tts.NewGoogle(ctx, credentialPath, opts...)
+		if err != nil {
+			return nil, errors.New("Bad credentials file - make sure the path is correct\n" + err.Error())
+		}
+		return google, nil
+	case endpoint != "":
+		return tts.NewGoogleAt(ctx, endpoint)
+	}
+	return nil, nil
+}
+
+// listVoices prints the voices the Google backend offers for a language code.
+func listVoices(ctx context.Context, synthesizer tts.Synthesizer, languageCode string) {
+	google, ok := synthesizer.(*tts.Google)
+	if !ok {
+		log.Fatal("Listing voices needs a Google Cloud credentials file.")
+	}
+
+	voices, err := google.ListVoices(ctx, languageCode)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "NAME\tLANGUAGE\tGENDER")
+	for _, v := range voices {
+		fmt.Fprintf(w, "%s\t%s\t%s\n", v.Name, v.LanguageCode, v.Gender)
+	}
+	w.Flush()
+}
+
+// beeTemplate returns the SSML template for spelling-bee mode,
+// read from path or the built-in word, sentence, word template if path is empty.
+func beeTemplate(path string) (*template.Template, error) {
+	text := tts.BeeTemplate
+	if path != "" {
+		b, err := os.ReadFile(path)
+		if err != nil {
+			return nil, err
+		}
+		text = string(b)
+	}
+	return tts.ParseTemplate(text)
+}
+
+// describeWord returns a tts.Describe function that looks up a word's first definition in definitions
+// and its example sentence from sentences, or else from the definitions' examples.
+// Lookups may go to the network, so it runs in the background of tts.Prefetch and SayWord, never in Update.
+func describeWord(definitions definition.Provider, sentences map[string]string) func(context.Context, string) tts.Utterance {
+	return func(ctx context.Context, word string) tts.Utterance {
+		u := tts.Utterance{Word: word, Sentence: sentences[word]}
+		if entries, _ := definitions.Lookup(ctx, word); len(entries) > 0 {
+			u.Definition = entries[0].Definition
+			for _, e := range entries {
+				if u.Sentence == "" && len(e.Examples) > 0 {
+					u.Sentence = e.Examples[0]
+				}
+			}
+		}
+		return u
+	}
 }
 
 type wordMessage struct {
-	word       string
-	definition string
+	result definition.Result // the word and its definitions, installed into the definition state by Update
 }
 
-type correctMessage struct{}
+type correctMessage struct {
+	homophone string // the answer, if it was accepted for sounding like the word
+}
 type incorrectMessage struct{}
 
 type model struct {
@@ -56,7 +233,6 @@ type model struct {
 	streak          int
 	correction      string
 	definition      string
-	credentialPath  string
 	word            string
 	initialTime     time.Time
 	finalTime       time.Time
@@ -64,68 +240,91 @@ type model struct {
 	height          int
 	definitionState *definition.State
 	ttsState        *tts.TTS
+	words           api.WordSource
+	reviews         *review.Source     // records attempts and schedules missed words; nil disables reviews
+	unsavedReviews  int                // attempts recorded since the review schedule was last saved
+	drill           *morphology.Drill  // prompts for the forms of word families; nil outside of drills
+	prompt          string             // what to spell the word as in a drill, e.g. "Spell the plural of box"
+	homophones      homophone.Groups   // words that sound alike
+	homophoneMode   homophone.Mode     // how to handle words that sound like others
+	sentences       map[string]string  // example sentences from the word lists, by word
+	missing         definition.Missing // what to do with words without definitions
+	sentence        string             // a sentence using the word, once asked for
+	speakSentences  bool               // read sentences aloud when they are shown
+	skipped         int                // words skipped in a row for having no definitions
+	waiting         bool               // the next word is being looked up, so answers are ignored
+	upcoming        []string           // words whose audio is being prefetched, next word first
+	slowReplays     int                // how many times the current word was replayed slowly
+	volume          int                // playback volume level, see audio.Player
+	status          string             // speech errors and other notices for the status bar
+	speechFailures  int                // speech failures in a row
+	textOnly        bool               // speech gave up after too many failures
 	borderColor     lipgloss.Color
 }
 
-// initialModel initializes the model with a text input field and a random word.
-func initialModel(credentialPath string, ctx context.Context) model {
+// initialModel initializes the model with a text input field and the first word from words.
+// Words are spoken through ttsState and looked up in definitions.
+// From Init on, the audio for the next prefetch words is synthesized in the background while the user types.
+func initialModel(ttsState *tts.TTS, definitions definition.Provider, words api.WordSource, prefetch int) model {
 	ti := textinput.New()
 	ti.Placeholder = "spell spoken word..."
 	ti.Focus()
 	ti.CharLimit = 156
 	ti.Width = 20
 
-	state := &definition.State{}
+	state := &definition.State{Provider: definitions}
 
-	ttsState := &tts.TTS{}
-	ttsState.Ctx = ctx
+	// Get the first word; Init looks up its definition.
+	word := words.Next()
+
+	// Pick the words after it now so their audio is ready when they come up.
+	upcoming := make([]string, max(prefetch, 0))
+	for i := range upcoming {
+		upcoming[i] = words.Next()
+	}
 
-	// Get a random word and its definition.
-	word := api.RandomWord()
 	return model{
 		textInput:       ti,
-		credentialPath:  credentialPath,
 		correction:      "\n",
 		word:            word,
 		definitionState: state,
-		definition:      state.GetDefinition(word),
 		ttsState:        ttsState,
+		words:           words,
+		upcoming:        upcoming,
 	}
 }
 
 func (m *model) Init() tea.Cmd {
-	if m.credentialPath != "" {
-		// User provided custom credentials file
-		client, err := texttospeech.NewClient(m.ttsState.Ctx, option.WithCredentialsFile(m.credentialPath))
-		if err != nil {
-			tea.ExitAltScreen()
-			log.Fatal("Bad credentials file - make sure the path is correct\n" + err.Error())
-		}
-		m.ttsState.Client = client
-	} else {
-		tea.ExitAltScreen()
-		log.Fatal("Please provide a Google Cloud credentials file.")
+	m.prefetch(m.upcoming...)
+	return tea.Batch(textinput.Blink, lookUp(m, m.word))
+}
+
+// nextWord takes the next word off the prefetch queue and tops the queue back up.
+// With prefetching disabled it simply returns the next word from the source.
+func (m *model) nextWord() string {
+	if len(m.upcoming) == 0 {
+		return m.words.Next()
 	}
 
-	m.ttsState.Word = m.word
-	go m.ttsState.SayWord()
-	return textinput.Blink
+	word := m.upcoming[0]
+	added := m.words.Next()
+	m.upcoming = append(m.upcoming[1:], added)
+	m.prefetch(added)
+	return word
 }
 
 // Command to generate a new word.
 func getNewWord(m *model) tea.Cmd {
-	return func() tea.Msg {
-		word := api.RandomWord()
-		def := m.definitionState.GetDefinition(word)
-
-		// Play the word audio in a goroutine to avoid blocking.
-		m.ttsState.Word = word
-		go m.ttsState.SayWord()
+	return lookUp(m, m.nextWord())
+}
 
-		return wordMessage{
-			word:       word,
-			definition: def,
-		}
+// Command to look up the definition of word, which then comes up as a wordMessage.
+// The lookup may go to the network, so it leaves the definition state alone while the user keeps typing.
+func lookUp(m *model, word string) tea.Cmd {
+	m.waiting = true
+	state, ctx := m.definitionState, m.ttsState.Ctx
+	return func() tea.Msg {
+		return wordMessage{result: state.LookUp(ctx, word)}
 	}
 }
 
@@ -133,13 +332,31 @@ func getNewWord(m *model) tea.Cmd {
 func (m *model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	switch msg := msg.(type) {
 	case wordMessage:
+		if m.skipsUndefined(msg) {
+			m.skipped++
+			return m, getNewWord(m)
+		}
+		m.skipped = 0
+		m.waiting = false
+
 		// Update model with new word.
-		m.word = msg.word
-		m.definition = msg.definition
+		def, err := m.definitionState.Show(msg.result)
+		m.word = msg.result.Word
+		m.definition = def
+		if err != nil {
+			m.definition = m.missingDefinition(m.word, err)
+		}
+		m.prompt = m.promptFor(m.word)
+		m.sentence = ""
+		m.slowReplays = 0
+		return m, m.sayWord(m.word)
+
+	case speechMessage:
+		m.handleSpeech(msg)
 		return m, nil
 
 	case tea.KeyMsg:
-		if m.textInput.Value() == "" && msg.Type != tea.KeyCtrlR {
+		if m.textInput.Value() == "" && !isAudioKey(msg) {
 			m.initialTime = time.Now() // start timer on first key press.
 		}
 
@@ -151,38 +368,57 @@ func (m *model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		case tea.KeyCtrlC, tea.KeyEsc, tea.KeyCtrlD: // exit.
 			return m, tea.Quit
 		case tea.KeyCtrlR: // repeat word.
-			m.ttsState.Word = m.word
-			go m.ttsState.SayWord()
+			return m, m.sayWord(m.word)
+		case tea.KeyCtrlS: // repeat word slowly, slower on every press.
+			m.slowReplays++
+			return m, m.sayWordSlowly(m.word, m.slowReplays)
+		case tea.KeyCtrlT: // retry speech after an error.
+			return m, m.retrySpeech()
+		case tea.KeyCtrlE: // use the word in a sentence.
+			return m, m.showSentence()
+		case tea.KeyPgUp: // turn volume up.
+			m.volume = m.ttsState.Player.AdjustVolume(1)
+			return m, nil
+		case tea.KeyPgDown: // turn volume down.
+			m.volume = m.ttsState.Player.AdjustVolume(-1)
 			return m, nil
 		case tea.KeyDown:
 			// If the user presses down, we want to get the next definition.
-			m.definition = m.definitionState.NextDefinition()
+			// Words without definitions keep showing what stands in for one.
+			m.definition = cmp.Or(m.definitionState.NextDefinition(), m.definition)
 		case tea.KeyUp:
 			// If the user presses up, we want to get the previous definition.
-			m.definition = m.definitionState.PrevDefinition()
+			m.definition = cmp.Or(m.definitionState.PrevDefinition(), m.definition)
 		}
 
 	case tea.WindowSizeMsg:
 		m.width = msg.Width
 		m.height = msg.Height
 
-
 	case correctMessage:
-        var correctColor lipgloss.Color = lipgloss.Color("#66ac5a") // og
+		var correctColor lipgloss.Color = lipgloss.Color("#66ac5a") // og
 		m.streak++
 		m.definition = wordwrap.String(m.definition, 100)
 		m.borderColor = correctColor // Set border color to green for correct answer
 
 		m.correction = ""
+		if msg.homophone != "" {
+			m.correction = fmt.Sprintf("%s sounds the same, but the word was %s", msg.homophone, m.word)
+		}
+		m.correction = m.withRevealed(m.correction)
 		return m, getNewWord(m)
 
 	case incorrectMessage:
-        var incorrectColor lipgloss.Color = lipgloss.Color("#ED4337") // og
+		var incorrectColor lipgloss.Color = lipgloss.Color("#ED4337") // og
 		m.streak = 0
 		m.definition = wordwrap.String(m.definition, 100)
 		m.borderColor = incorrectColor // Set border color to red for incorrect answer
 
 		m.correction = fmt.Sprintf("Correct spelling: %s", m.word)
+		if hint := m.drillHint(m.word); hint != "" {
+			m.correction += fmt.Sprintf(" (%s)", hint)
+		}
+		m.correction = m.withRevealed(m.correction)
 		return m, getNewWord(m)
 	}
 
@@ -192,20 +428,37 @@ func (m *model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	return m, cmd
 }
 
+// isAudioKey reports whether a key controls playback rather than typing,
+// so that pressing it doesn't start the WPM timer.
+func isAudioKey(msg tea.KeyMsg) bool {
+	switch msg.Type {
+	case tea.KeyCtrlR, tea.KeyCtrlS, tea.KeyCtrlT, tea.KeyCtrlE, tea.KeyPgUp, tea.KeyPgDown:
+		return true
+	}
+	return false
+}
+
 // submitWord checks the user's input against the correct word.
 // If the input is correct, it returns a correctMessage.
 // If the input is incorrect, it returns an incorrectMessage.
-// It also resets the text input field.
+// It also resets the text input field. Answers are ignored until the next word has come up.
 func (m *model) submitWord() (tea.Model, tea.Cmd) {
-	if m.textInput.Value() == "" {
+	if m.textInput.Value() == "" || m.waiting {
 		return m, nil
 	}
 
 	userInput := m.textInput.Value()
 	m.textInput.Reset()
 
-	if userInput == m.word { // Correct answer.
+	m.waiting = true // until the next word comes up
+	correct := userInput == m.word
+	alike := !correct && m.acceptsHomophone(userInput, m.word)
+	m.recordAttempt(userInput, correct || alike)
+
+	if correct { // Correct answer.
 		return m, func() tea.Msg { return correctMessage{} }
+	} else if alike { // Sounds the same, which is all the user could tell.
+		return m, func() tea.Msg { return correctMessage{homophone: userInput} }
 	} else { // Incorrect answer.
 		return m, func() tea.Msg { return incorrectMessage{} }
 	}
@@ -213,17 +466,17 @@ func (m *model) submitWord() (tea.Model, tea.Cmd) {
 }
 
 func (m model) View() string {
-    var foregroundColor lipgloss.Color = lipgloss.Color("#cfd6f1")
-    var backgroundColor lipgloss.Color = lipgloss.Color("#1e1e2d")
+	var foregroundColor lipgloss.Color = lipgloss.Color("#cfd6f1")
+	var backgroundColor lipgloss.Color = lipgloss.Color("#1e1e2d")
 
 	// Create a container style for the main content
 	inputContainer := lipgloss.NewStyle().
 		Padding(1, 2).
 		Margin(1).
-        Foreground(lipgloss.Color(foregroundColor)).
-        Background(lipgloss.Color(backgroundColor)).
-        BorderForeground(lipgloss.Color(foregroundColor)).
-        BorderBackground(lipgloss.Color(backgroundColor)).
+		Foreground(lipgloss.Color(foregroundColor)).
+		Background(lipgloss.Color(backgroundColor)).
+		BorderForeground(lipgloss.Color(foregroundColor)).
+		BorderBackground(lipgloss.Color(backgroundColor)).
 		Border(lipgloss.RoundedBorder()).
 		Align(lipgloss.Center, lipgloss.Center)
 
@@ -233,9 +486,9 @@ func (m model) View() string {
 	inputView := lipgloss.NewStyle().
 		BorderStyle(lipgloss.RoundedBorder()).
 		BorderForeground(m.borderColor).
-        Foreground(lipgloss.Color(foregroundColor)).
-        Background(lipgloss.Color(backgroundColor)).
-        BorderBackground(lipgloss.Color(backgroundColor)).
+		Foreground(lipgloss.Color(foregroundColor)).
+		Background(lipgloss.Color(backgroundColor)).
+		BorderBackground(lipgloss.Color(backgroundColor)).
 		Render(m.textInput.View())
 
 	// Center the definition but keep it within the container's width
@@ -244,6 +497,24 @@ func (m model) View() string {
 		Width(width).
 		Render(m.definition)
 
+	// In a drill, say which form to spell above the definition
+	if m.prompt != "" {
+		definitionText = lipgloss.NewStyle().
+			Bold(true).
+			Align(lipgloss.Center).
+			Width(width).
+			Render(m.prompt) + "\n\n" + definitionText
+	}
+
+	// Below it, the sentence the user asked for
+	if m.sentence != "" {
+		definitionText += "\n\n" + lipgloss.NewStyle().
+			Italic(true).
+			Align(lipgloss.Center).
+			Width(width).
+			Render(m.sentence)
+	}
+
 	correctionText := lipgloss.NewStyle().
 		Align(lipgloss.Center).
 		Width(width).
@@ -258,10 +529,17 @@ func (m model) View() string {
 
 	// Style for the status bar at the bottom
 	renderString := fmt.Sprintf(
-		"Gospell: Press 'ESC' / 'CtrlC' to exit, 'CtrlR' to repeat word, ↑/↓ to navigate definitions | Current WPM: %d | Streak: %d",
+		"Gospell: Press 'ESC' / 'CtrlC' to exit, 'CtrlR' to repeat word, 'CtrlS' to repeat slowly, 'CtrlE' for a sentence, ↑/↓ to navigate definitions, PgUp/PgDn for volume | Current WPM: %d | Streak: %d | Volume: %+d",
 		wpm.CalculateWpm(m.textInput.Value(), m.initialTime, m.finalTime),
 		m.streak,
+		m.volume,
 	)
+	if due := m.dueReviews(); due > 0 {
+		renderString += fmt.Sprintf(" | Reviews due: %d", due)
+	}
+	if m.status != "" {
+		renderString += " | " + m.status
+	}
 
 	statusBar := lipgloss.NewStyle().
 		Background(lipgloss.Color("#cfd6f1")).
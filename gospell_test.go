@@ -0,0 +1,253 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/jharlan-hash/gospell/internal/audio"
+	"github.com/jharlan-hash/gospell/internal/definition"
+	"github.com/jharlan-hash/gospell/internal/homophone"
+	"github.com/jharlan-hash/gospell/internal/tts"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// sequence is a WordSource that returns its words in order, then the last one over and over.
+type sequence struct {
+	words []string
+	next  int
+}
+
+func (s *sequence) Next() string {
+	word := s.words[min(s.next, len(s.words)-1)]
+	s.next++
+	return word
+}
+
+func (s *sequence) Len() int { return len(s.words) }
+func (s *sequence) Reset()   { s.next = 0 }
+
+// newTestModel returns a silent model that looks words up in definitions and practices words in order.
+func newTestModel(t *testing.T, definitions definition.Dictionary, words ...string) *model {
+	t.Helper()
+	ttsState := &tts.TTS{Synthesizer: tts.Silent{}, Player: &audio.Discard{}, Ctx: context.Background()}
+	m := initialModel(ttsState, definitions, &sequence{words: words}, 0)
+	return &m
+}
+
+// update sends msg to the model and returns the message its command comes up with, or nil if there is none.
+func update(m *model, msg tea.Msg) tea.Msg {
+	_, cmd := m.Update(msg)
+	if cmd == nil {
+		return nil
+	}
+	return cmd()
+}
+
+// start shows the model's first word, as Init and the lookup it starts would.
+func start(t *testing.T, m *model) {
+	t.Helper()
+	msg := lookUp(m, m.word)()
+	if next := update(m, msg); next == nil {
+		t.Fatalf("showing %q didn't speak it", m.word)
+	}
+}
+
+// answer types answer and submits it, returning the message the submission comes up with.
+func answer(m *model, answer string) tea.Msg {
+	update(m, tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(answer)})
+	return update(m, tea.KeyMsg{Type: tea.KeyEnter})
+}
+
+func TestModel_UpdateSkipsUndefined(t *testing.T) {
+	d := definition.Dictionary{}
+	d.Add("cat", "noun", "a small feline")
+
+	tests := []struct {
+		name    string // description of this test case
+		missing definition.Missing
+		words   []string
+		want    string // the word that comes up
+		skips   int    // words passed over before it
+	}{
+		{"TestSkipsToDefinedWord", definition.Skip, []string{"xyzzy", "plugh", "cat"}, "cat", 2},
+		{"TestStopsAfterMaxSkips", definition.Skip, []string{"xyzzy"}, "xyzzy", maxSkips},
+		{"TestShowsUndefinedWord", definition.Show, []string{"xyzzy", "cat"}, "xyzzy", 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := newTestModel(t, d, tt.words...)
+			m.missing = tt.missing
+
+			msg, skips := lookUp(m, m.word)(), 0
+			for ; skips <= maxSkips; skips++ {
+				next := update(m, msg)
+				if _, ok := next.(wordMessage); !ok {
+					break
+				}
+				msg = next
+			}
+			if m.word != tt.want || m.waiting {
+				t.Errorf("word = %q, waiting = %v, want %q to come up", m.word, m.waiting, tt.want)
+			}
+			if skips != tt.skips {
+				t.Errorf("skipped %d words, want %d", skips, tt.skips)
+			}
+			if m.skipped != 0 {
+				t.Errorf("skipped = %d once a word came up, want 0", m.skipped)
+			}
+		})
+	}
+}
+
+// synthesized records the words a backend is asked to speak, producing no audio.
+type synthesized struct {
+	mu    sync.Mutex
+	words []string
+}
+
+func (s *synthesized) SynthesizeSpeech(ctx context.Context, req tts.Request) ([]byte, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.words = append(s.words, req.Text)
+	return nil, nil
+}
+
+func (s *synthesized) has(word string) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return slices.Contains(s.words, word)
+}
+
+func TestModel_PrefetchSkipsUndefined(t *testing.T) {
+	d := definition.Dictionary{}
+	d.Add("cat", "noun", "a small feline")
+	d.Add("dog", "noun", "a domesticated canine")
+
+	synth := &synthesized{}
+	ttsState := &tts.TTS{Synthesizer: synth, Player: &audio.Discard{}, Ctx: context.Background()}
+	m := initialModel(ttsState, d, &sequence{words: []string{"cat", "xyzzy", "dog"}}, 2)
+	m.missing = definition.Skip
+	m.Init()
+
+	for deadline := time.Now().Add(5 * time.Second); !synth.has("dog"); time.Sleep(time.Millisecond) {
+		if time.Now().After(deadline) {
+			t.Fatal("dog was never prefetched")
+		}
+	}
+	if synth.has("xyzzy") {
+		t.Error("xyzzy was prefetched, want words that are going to be skipped left out")
+	}
+}
+
+func TestModel_UpdateShowsFailedLookup(t *testing.T) {
+	m := newTestModel(t, definition.Dictionary{}, "cat", "dog")
+	m.missing = definition.Skip
+
+	update(m, wordMessage{result: definition.Result{Word: "cat", Err: errors.New("offline")}})
+	if m.word != "cat" || m.waiting {
+		t.Errorf("word = %q, waiting = %v, want a failed lookup shown rather than skipped", m.word, m.waiting)
+	}
+	if !strings.Contains(m.definition, "offline") {
+		t.Errorf("definition = %q, want it to say why the lookup failed", m.definition)
+	}
+}
+
+func TestModel_UpdateIgnoresAnswersWhileWaiting(t *testing.T) {
+	d := definition.Dictionary{}
+	d.Add("cat", "noun", "a small feline")
+	d.Add("dog", "noun", "a domesticated canine")
+	m := newTestModel(t, d, "cat", "dog")
+
+	m.waiting = true // as Init leaves it until the first word comes up
+	if msg := answer(m, "cat"); msg != nil {
+		t.Errorf("answering before the word came up = %#v, want it ignored", msg)
+	}
+	m.textInput.Reset()
+
+	start(t, m)
+	if msg := answer(m, "cat"); msg != (correctMessage{}) {
+		t.Fatalf("answering cat = %#v, want correctMessage", msg)
+	}
+	if !m.waiting {
+		t.Fatal("not waiting for the next word after an answer")
+	}
+	if msg := answer(m, "cat"); msg != nil {
+		t.Errorf("answering twice = %#v, want the second answer ignored", msg)
+	}
+}
+
+func TestModel_UpdateAcceptsHomophone(t *testing.T) {
+	d := definition.Dictionary{}
+	d.Add("pair", "noun", "two things of the same kind")
+	d.Add("cat", "noun", "a small feline")
+
+	tests := []struct {
+		name string // description of this test case
+		mode homophone.Mode
+		want tea.Msg
+	}{
+		{"TestAccept", homophone.Accept, correctMessage{homophone: "pear"}},
+		{"TestDefine", homophone.Define, incorrectMessage{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := newTestModel(t, d, "pair", "cat")
+			m.homophones = homophone.Parse("pair pare pear")
+			m.homophoneMode = tt.mode
+			start(t, m)
+
+			msg := answer(m, "pear")
+			if msg != tt.want {
+				t.Fatalf("answering pear = %#v, want %#v", msg, tt.want)
+			}
+			if _, ok := update(m, msg).(wordMessage); !ok {
+				t.Error("the answer didn't bring up the next word")
+			}
+			if tt.mode != homophone.Accept {
+				return
+			}
+			if m.streak != 1 {
+				t.Errorf("streak = %d, want 1", m.streak)
+			}
+			if want := "pear sounds the same, but the word was pair"; !strings.Contains(m.correction, want) {
+				t.Errorf("correction = %q, want it to contain %q", m.correction, want)
+			}
+		})
+	}
+}
+
+func TestModel_UpdateReveals(t *testing.T) {
+	d := definition.Dictionary{}
+	d.Add("hop", "verb", "to hop on one foot")
+	d.Add("cat", "noun", "a small feline")
+
+	tests := []struct {
+		name   string // description of this test case
+		answer string
+		want   string // the correction
+	}{
+		{"TestCorrect", "hop", "(1 of 1) verb: to hop on one foot"},
+		{"TestIncorrect", "hopp", "Correct spelling: hop\n(1 of 1) verb: to hop on one foot"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := newTestModel(t, d, "hop", "cat")
+			m.definitionState.Masked = true
+			start(t, m)
+			if want := "(1 of 1) verb: to " + definition.Blank + " on one foot"; m.definition != want {
+				t.Fatalf("definition = %q, want %q", m.definition, want)
+			}
+
+			update(m, answer(m, tt.answer))
+			if m.correction != tt.want {
+				t.Errorf("correction = %q, want %q", m.correction, tt.want)
+			}
+		})
+	}
+}
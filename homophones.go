@@ -0,0 +1,34 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/jharlan-hash/gospell/internal/homophone"
+)
+
+// promptFor returns what to show above the definition of word: which form to spell in a drill,
+// or what tells it apart from the words that sound like it.
+func (m *model) promptFor(word string) string {
+	if prompt := m.drillPrompt(word); prompt != "" {
+		return prompt // the lemma and the form already tell homophones apart
+	}
+	return m.homophonePrompt(word)
+}
+
+// homophonePrompt returns, for a word that sounds like others in homophone.Define mode,
+// its example sentence with the word and its forms blanked out, or a pointer to its definition. Otherwise it returns "".
+func (m *model) homophonePrompt(word string) string {
+	if m.homophoneMode != homophone.Define || !m.homophones.Has(word) {
+		return ""
+	}
+	if sentence := m.maskedSentence(word); sentence != "" {
+		return fmt.Sprintf("Sounds like another word. Spell the word in: %q", sentence)
+	}
+	return "Sounds like another word. Spell the one with this meaning:"
+}
+
+// acceptsHomophone reports whether answer, though not the word, sounds like it and counts as correct
+// in homophone.Accept mode.
+func (m *model) acceptsHomophone(answer, word string) bool {
+	return m.homophoneMode == homophone.Accept && m.homophones.Alike(answer, word)
+}
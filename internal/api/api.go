@@ -7,15 +7,19 @@ import (
 	"strings"
 )
 
+// The embedded word list is curated with "gospell lists lint", which drops
+// acronyms, profanity and other entries that make poor spelling practice.
+// It also drops words the embedded dictionary can't define, so generate
+// internal/definition/wordmap.gob before regenerating the list.
+//go:generate go run ../.. lists lint --fix wordlist.txt
+
 //go:embed wordlist.txt
 var fileString string
 var file []string = splitWords(fileString)
-var rng = NewRand()
 
-// RandomWord returns a random word from the wordlist.
-func RandomWord() string {
-	randomNumber := rng.Intn(len(file))
-	return file[randomNumber]
+// EmbeddedText returns the embedded word list as stored, one word per line.
+func EmbeddedText() string {
+	return fileString
 }
 
 // Rand64 returns a pseudo-random uint64. It can be used concurrently and is lock-free.
@@ -30,6 +34,8 @@ func NewRand() *rand.Rand {
 	return rand.New(rand.NewSource(int64(Rand64())))
 }
 
+// splitWords splits a word list into its lines, leaving out blank ones
+// such as the empty string after the final newline.
 func splitWords(wordlist string) []string {
-	return strings.Split(wordlist, "\n")
+	return strings.FieldsFunc(wordlist, func(r rune) bool { return r == '\n' || r == '\r' })
 }
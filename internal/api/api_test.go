@@ -3,7 +3,7 @@ package api
 import "testing"
 
 func TestGetRandomLineFromWordlist(t *testing.T) {
-	word := RandomWord()
+	word := Embedded().Next()
 	t.Logf("Random word: %v", word)
 
 	if len(word) == 0 {
@@ -19,15 +19,16 @@ func TestSplitWords(t *testing.T) {
     if len(words) == 0 {
         t.Errorf("Expected a non-empty list of words, got empty list")
     }
-    if len(words) != 90632 {
-        t.Errorf("Expected 90632 words, got %d", len(words))
+    if len(words) != 90005 {
+        t.Errorf("Expected 90005 words, got %d", len(words))
     }
 }
 
 func BenchmarkRuntimeRandomWord(b *testing.B) {
 	var word string
+	words := Embedded()
 	for b.Loop() {
-		word = RandomWord()
+		word = words.Next()
 	}
 	b.Logf("Random word: %v", word)
 }
@@ -0,0 +1,152 @@
+package api
+
+import (
+	_ "embed"
+	"fmt"
+	"math/rand"
+	"sort"
+	"strings"
+	"sync"
+)
+
+//go:embed frequency.txt
+var frequencyFile string
+
+// Frequency maps words to their frequency rank: 1 is the most frequent word.
+// It implements difficulty.Ranker.
+type Frequency map[string]int
+
+// Rank returns the rank of word, or false if the corpus doesn't contain it.
+func (f Frequency) Rank(word string) (int, bool) {
+	rank, ok := f[word]
+	return rank, ok
+}
+
+// EmbeddedFrequency returns the frequency ranks of the words of the embedded word list,
+// taken from a corpus of TV and film subtitles.
+var EmbeddedFrequency = sync.OnceValue(func() Frequency {
+	return ReadFrequency(frequencyFile)
+})
+
+// ReadFrequency reads a frequency table, one word per line with the most frequent first.
+// Blank lines and lines starting with # are skipped.
+func ReadFrequency(table string) Frequency {
+	f := make(Frequency)
+	for _, line := range strings.Split(table, "\n") {
+		word := strings.TrimSpace(line)
+		if word == "" || strings.HasPrefix(word, "#") {
+			continue
+		}
+		if _, ok := f[word]; !ok {
+			f[word] = len(f) + 1
+		}
+	}
+	return f
+}
+
+// Band is a range of frequency ranks.
+type Band string
+
+const (
+	AnyBand  Band = ""
+	Common   Band = "common"   // the 5,000 most frequent words
+	Uncommon Band = "uncommon" // ranks 5,001 to 20,000
+	Rare     Band = "rare"     // everything else, including words the corpus doesn't contain
+)
+
+// Bands lists every Band but AnyBand, from the most frequent words to the rarest.
+var Bands = []Band{Common, Uncommon, Rare}
+
+// bandEnds are the last ranks of Common and Uncommon.
+const (
+	commonEnd   = 5_000
+	uncommonEnd = 20_000
+)
+
+// ParseBand returns the Band named s, where the empty string is AnyBand.
+func ParseBand(s string) (Band, error) {
+	b := Band(strings.ToLower(s))
+	if b == AnyBand {
+		return b, nil
+	}
+	for _, band := range Bands {
+		if b == band {
+			return b, nil
+		}
+	}
+	return "", fmt.Errorf("unknown frequency band %q, want common, uncommon or rare", s)
+}
+
+// Band returns the frequency band of word.
+func (f Frequency) Band(word string) Band {
+	rank, ok := f[word]
+	switch {
+	case !ok || rank > uncommonEnd:
+		return Rare
+	case rank > commonEnd:
+		return Uncommon
+	}
+	return Common
+}
+
+// Contains reports whether word is in band b; every word is in AnyBand.
+func (f Frequency) Contains(b Band, word string) bool {
+	return b == AnyBand || f.Band(word) == b
+}
+
+// Weight returns how likely word is picked when sampling by frequency, relative to other words.
+// It is inversely proportional to the rank, as word frequencies are by Zipf's law, so a session mostly
+// brings up words the user will actually meet; words the corpus doesn't contain count as ranked just past its end.
+func (f Frequency) Weight(word string) float64 {
+	rank, ok := f[word]
+	if !ok {
+		rank = len(f) + 1
+	}
+	return 1 / float64(rank)
+}
+
+// Weighted is a WordSource that picks words at random in proportion to their weights.
+type Weighted struct {
+	words      []string
+	cumulative []float64 // running total of the weights, for binary search
+	seed       int64
+	rng        *rand.Rand
+}
+
+// NewWeighted returns a Weighted source of words with a random seed. Words of weight 0 or less are never picked.
+func NewWeighted(words []string, weight func(word string) float64) *Weighted {
+	w := &Weighted{seed: int64(Rand64())}
+	total := 0.0
+	for _, word := range words {
+		if wt := weight(word); wt > 0 {
+			total += wt
+			w.words = append(w.words, word)
+			w.cumulative = append(w.cumulative, total)
+		}
+	}
+	w.Reset()
+	return w
+}
+
+// Next returns a random word, or "" if there are none.
+func (w *Weighted) Next() string {
+	if len(w.words) == 0 {
+		return ""
+	}
+	total := w.cumulative[len(w.cumulative)-1]
+	i := sort.SearchFloat64s(w.cumulative, w.rng.Float64()*total)
+	return w.words[min(i, len(w.words)-1)]
+}
+
+func (w *Weighted) Len() int {
+	return len(w.words)
+}
+
+func (w *Weighted) Reset() {
+	w.rng = rand.New(rand.NewSource(w.seed))
+}
+
+func (w *Weighted) Seed(seed int64) {
+	w.seed = seed
+	w.Reset()
+}
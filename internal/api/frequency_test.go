@@ -0,0 +1,126 @@
+package api_test
+
+import (
+	"testing"
+
+	"github.com/jharlan-hash/gospell/internal/api"
+)
+
+func TestReadFrequency(t *testing.T) {
+	f := api.ReadFrequency("# comment\nthe\n\nof\nthe\nand\n")
+	tests := []struct {
+		word     string
+		wantRank int
+		wantOK   bool
+	}{
+		{"the", 1, true},
+		{"of", 2, true},
+		{"and", 3, true},
+		{"# comment", 0, false},
+		{"syzygy", 0, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.word, func(t *testing.T) {
+			rank, ok := f.Rank(tt.word)
+			if rank != tt.wantRank || ok != tt.wantOK {
+				t.Errorf("Rank(%q) = %d, %v, want %d, %v", tt.word, rank, ok, tt.wantRank, tt.wantOK)
+			}
+		})
+	}
+}
+
+func TestFrequency_Band(t *testing.T) {
+	f := api.Frequency{"common": 1, "edge": 5_000, "uncommon": 5_001, "last": 20_000, "rare": 20_001}
+	tests := []struct {
+		word string
+		want api.Band
+	}{
+		{"common", api.Common},
+		{"edge", api.Common},
+		{"uncommon", api.Uncommon},
+		{"last", api.Uncommon},
+		{"rare", api.Rare},
+		{"missing", api.Rare},
+	}
+	for _, tt := range tests {
+		t.Run(tt.word, func(t *testing.T) {
+			if got := f.Band(tt.word); got != tt.want {
+				t.Errorf("Band(%q) = %q, want %q", tt.word, got, tt.want)
+			}
+			if !f.Contains(api.AnyBand, tt.word) {
+				t.Errorf("Contains(AnyBand, %q) = false, want true", tt.word)
+			}
+		})
+	}
+}
+
+func TestParseBand(t *testing.T) {
+	tests := []struct {
+		input   string
+		want    api.Band
+		wantErr bool
+	}{
+		{"", api.AnyBand, false},
+		{"common", api.Common, false},
+		{"Rare", api.Rare, false},
+		{"obscure", "", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			got, err := api.ParseBand(tt.input)
+			if (err != nil) != tt.wantErr || got != tt.want {
+				t.Errorf("ParseBand(%q) = %q, %v, want %q, wantErr %v", tt.input, got, err, tt.want, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestEmbeddedFrequency(t *testing.T) {
+	f := api.EmbeddedFrequency()
+	if f.Band("people") != api.Common {
+		t.Errorf("Band(%q) = %q, want common", "people", f.Band("people"))
+	}
+	if f.Band("syzygy") != api.Rare {
+		t.Errorf("Band(%q) = %q, want rare", "syzygy", f.Band("syzygy"))
+	}
+	if f.Weight("people") <= f.Weight("syzygy") {
+		t.Errorf("Weight(%q) = %g, want more than Weight(%q) = %g", "people", f.Weight("people"), "syzygy", f.Weight("syzygy"))
+	}
+}
+
+func TestWeighted_EmbeddedFrequency(t *testing.T) {
+	f := api.EmbeddedFrequency()
+	w := api.NewWeighted(api.Embedded().Words(), f.Weight)
+	w.Seed(1)
+
+	counts := make(map[api.Band]int)
+	for _, word := range draw(w, 10000) {
+		counts[f.Band(word)]++
+	}
+	// the list holds far more rare words than common ones, so weighting has to outweigh that
+	if counts[api.Common] < 5000 {
+		t.Errorf("drew %v by band, want mostly common words", counts)
+	}
+}
+
+func TestWeighted(t *testing.T) {
+	weights := map[string]float64{"often": 3, "sometimes": 1, "never": 0}
+	w := api.NewWeighted([]string{"often", "sometimes", "never"}, func(word string) float64 { return weights[word] })
+	w.Seed(1)
+
+	if w.Len() != 2 {
+		t.Errorf("Len() = %d, want 2 as words of weight 0 are left out", w.Len())
+	}
+
+	counts := make(map[string]int)
+	for _, word := range draw(w, 4000) {
+		counts[word]++
+	}
+	if counts["never"] > 0 {
+		t.Errorf("drew a word of weight 0 %d times", counts["never"])
+	}
+	// expect 3000 and 1000, give or take
+	if counts["often"] < 2800 || counts["often"] > 3200 {
+		t.Errorf("drew %v, want about 3 often for every sometimes", counts)
+	}
+}
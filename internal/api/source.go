@@ -0,0 +1,247 @@
+package api
+
+import (
+	"bufio"
+	"io"
+	"math/rand"
+	"os"
+	"strings"
+)
+
+// WordSource supplies the words to practice.
+type WordSource interface {
+	// Next returns the next word to practice.
+	Next() string
+	// Len returns how many words the source can choose from.
+	Len() int
+	// Reset starts the source over, so it repeats the sequence of words it has returned so far.
+	Reset()
+}
+
+// Seeder is a WordSource whose sequence of words is determined by a seed.
+// Sources built from the same words and seeded alike return the same words in the same order.
+type Seeder interface {
+	// Seed sets the seed and starts the source over.
+	Seed(seed int64)
+}
+
+// Seed seeds s if it is a Seeder, and reports whether it was.
+func Seed(s WordSource, seed int64) bool {
+	seeder, ok := s.(Seeder)
+	if ok {
+		seeder.Seed(seed)
+	}
+	return ok
+}
+
+// List is a WordSource that picks words at random from a fixed list.
+// By default every pick is independent, so a word may come up twice in a row;
+// a shuffle bag instead hands out every word once, in random order, before starting over.
+type List struct {
+	words []string
+	seed  int64
+	rng   *rand.Rand
+	bag   bool
+	order []int // shuffle bag: indexes of the words in the order they're handed out
+	next  int   // shuffle bag: position in order
+}
+
+// NewList returns a List of words with a random seed.
+func NewList(words []string) *List {
+	l := &List{words: words, seed: int64(Rand64())}
+	l.Reset()
+	return l
+}
+
+// NewBag returns a List of words that works as a shuffle bag, with a random seed.
+func NewBag(words []string) *List {
+	l := &List{words: words, seed: int64(Rand64()), bag: true}
+	l.Reset()
+	return l
+}
+
+// Embedded returns a List of the words in the embedded wordlist.
+func Embedded() *List {
+	return NewList(file)
+}
+
+// FromFile returns a List of the words in a file, one word per line.
+func FromFile(path string) (*List, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	return FromReader(f)
+}
+
+// FromReader returns a List of the words read from r, one word per line,
+// e.g. a word list piped to stdin.
+func FromReader(r io.Reader) (*List, error) {
+	words, err := ReadWords(r)
+	if err != nil {
+		return nil, err
+	}
+	return NewList(words), nil
+}
+
+// ReadWords reads one word per line from r, skipping blank lines.
+func ReadWords(r io.Reader) ([]string, error) {
+	var words []string
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		if word := strings.TrimSpace(scanner.Text()); word != "" {
+			words = append(words, word)
+		}
+	}
+	return words, scanner.Err()
+}
+
+// Next returns a random word from the list, or "" if the list is empty.
+func (l *List) Next() string {
+	if len(l.words) == 0 {
+		return ""
+	}
+	if !l.bag {
+		return l.words[l.rng.Intn(len(l.words))]
+	}
+
+	if l.next == len(l.order) {
+		last := l.order[len(l.order)-1]
+		l.shuffle()
+		if len(l.order) > 1 && l.order[0] == last {
+			// don't let the new round start with the word that ended the last one
+			l.order[0], l.order[len(l.order)-1] = l.order[len(l.order)-1], l.order[0]
+		}
+	}
+	word := l.words[l.order[l.next]]
+	l.next++
+	return word
+}
+
+// shuffle puts the bag in a new random order.
+func (l *List) shuffle() {
+	l.order = l.rng.Perm(len(l.words))
+	l.next = 0
+}
+
+// Shuffled returns a shuffle bag of the same words, with the same seed.
+func (l *List) Shuffled() *List {
+	bag := &List{words: l.words, seed: l.seed, bag: true}
+	bag.Reset()
+	return bag
+}
+
+// Filter returns a new List of the words for which keep returns true, with the same seed and mode.
+func (l *List) Filter(keep func(word string) bool) *List {
+	var words []string
+	for _, word := range l.words {
+		if keep(word) {
+			words = append(words, word)
+		}
+	}
+	filtered := &List{words: words, seed: l.seed, bag: l.bag}
+	filtered.Reset()
+	return filtered
+}
+
+// Union returns a List of the words of lists, each word once in the order it first appears,
+// with a random seed and the mode of the first list. A shuffle bag of the union never repeats a word
+// until every word of every list came up, which a Composite of bags can't promise.
+func Union(lists ...*List) *List {
+	seen := make(map[string]bool)
+	var words []string
+	bag := false
+	for i, l := range lists {
+		if i == 0 {
+			bag = l.bag
+		}
+		for _, word := range l.words {
+			if !seen[word] {
+				seen[word] = true
+				words = append(words, word)
+			}
+		}
+	}
+	u := &List{words: words, seed: int64(Rand64()), bag: bag}
+	u.Reset()
+	return u
+}
+
+// Words returns the words of the list.
+func (l *List) Words() []string {
+	return l.words
+}
+
+func (l *List) Len() int {
+	return len(l.words)
+}
+
+func (l *List) Reset() {
+	l.rng = rand.New(rand.NewSource(l.seed))
+	if l.bag {
+		l.shuffle()
+	}
+}
+
+func (l *List) Seed(seed int64) {
+	l.seed = seed
+	l.Reset()
+}
+
+// Composite is a WordSource that draws from several sources.
+// Each source is picked in proportion to its Len, so every word across all of them is equally likely.
+type Composite struct {
+	sources []WordSource
+	seed    int64
+	rng     *rand.Rand
+}
+
+// NewComposite returns a Composite of sources.
+func NewComposite(sources ...WordSource) *Composite {
+	c := &Composite{sources: sources, seed: int64(Rand64())}
+	c.rng = rand.New(rand.NewSource(c.seed))
+	return c
+}
+
+// Next returns the next word of a randomly picked source, or "" if all sources are empty.
+func (c *Composite) Next() string {
+	total := c.Len()
+	if total == 0 {
+		return ""
+	}
+
+	n := c.rng.Intn(total)
+	for _, s := range c.sources {
+		if n < s.Len() {
+			return s.Next()
+		}
+		n -= s.Len()
+	}
+	return "" // unreachable while Len is the sum of the sources' lengths
+}
+
+func (c *Composite) Len() int {
+	total := 0
+	for _, s := range c.sources {
+		total += s.Len()
+	}
+	return total
+}
+
+// Seed seeds the Composite and, with seeds derived from seed, each of its sources.
+func (c *Composite) Seed(seed int64) {
+	c.seed = seed
+	seeds := rand.New(rand.NewSource(seed))
+	for _, s := range c.sources {
+		Seed(s, seeds.Int63())
+	}
+	c.Reset()
+}
+
+func (c *Composite) Reset() {
+	c.rng = rand.New(rand.NewSource(c.seed))
+	for _, s := range c.sources {
+		s.Reset()
+	}
+}
@@ -0,0 +1,204 @@
+package api_test
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/jharlan-hash/gospell/internal/api"
+)
+
+func TestReadWords(t *testing.T) {
+	tests := []struct {
+		name  string // description of this test case
+		input string
+		want  []string
+	}{
+		{"TestOneWordPerLine", "apple\nbanana\ncherry\n", []string{"apple", "banana", "cherry"}},
+		{"TestSkipsBlankLines", "apple\n\n  \nbanana", []string{"apple", "banana"}},
+		{"TestTrimsWhitespace", "  apple \r\n\tbanana\n", []string{"apple", "banana"}},
+		{"TestEmpty", "", nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := api.ReadWords(strings.NewReader(tt.input))
+			if err != nil {
+				t.Fatalf("ReadWords() error = %v", err)
+			}
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("ReadWords() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFromFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "words.txt")
+	if err := os.WriteFile(path, []byte("apple\nbanana\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	l, err := api.FromFile(path)
+	if err != nil {
+		t.Fatalf("FromFile() error = %v", err)
+	}
+	if l.Len() != 2 {
+		t.Errorf("Len() = %d, want 2", l.Len())
+	}
+	if word := l.Next(); word != "apple" && word != "banana" {
+		t.Errorf("Next() = %q, want a word from the file", word)
+	}
+
+	if _, err := api.FromFile(filepath.Join(t.TempDir(), "missing.txt")); err == nil {
+		t.Errorf("FromFile() of a missing file = nil, want error")
+	}
+}
+
+// draw returns the next n words of a source.
+func draw(s api.WordSource, n int) []string {
+	words := make([]string, n)
+	for i := range words {
+		words[i] = s.Next()
+	}
+	return words
+}
+
+func TestList_Reset(t *testing.T) {
+	l := api.Embedded()
+	first := draw(l, 20)
+	l.Reset()
+	if again := draw(l, 20); !slices.Equal(first, again) {
+		t.Errorf("after Reset() got %q, want %q", again, first)
+	}
+}
+
+func TestList_Empty(t *testing.T) {
+	l := api.NewList(nil)
+	if word := l.Next(); word != "" {
+		t.Errorf("Next() of an empty list = %q, want empty string", word)
+	}
+}
+
+func TestList_Filter(t *testing.T) {
+	l := api.NewList([]string{"a", "ox", "cat", "bird"}).Filter(func(word string) bool { return len(word) > 2 })
+	if l.Len() != 2 {
+		t.Errorf("Len() = %d, want 2", l.Len())
+	}
+	for _, word := range draw(l, 50) {
+		if word != "cat" && word != "bird" {
+			t.Errorf("Next() = %q, want cat or bird", word)
+		}
+	}
+}
+
+func TestComposite(t *testing.T) {
+	fruit := api.NewList([]string{"apple", "banana"})
+	veg := api.NewList([]string{"carrot"})
+	c := api.NewComposite(fruit, veg, api.NewList(nil))
+
+	if c.Len() != 3 {
+		t.Errorf("Len() = %d, want 3", c.Len())
+	}
+
+	seen := make(map[string]int)
+	for _, word := range draw(c, 300) {
+		seen[word]++
+	}
+	for _, word := range []string{"apple", "banana", "carrot"} {
+		if seen[word] == 0 {
+			t.Errorf("%q never drawn from %v", word, seen)
+		}
+	}
+	if len(seen) != 3 {
+		t.Errorf("drew %v, want only apple, banana and carrot", seen)
+	}
+
+	c.Reset()
+	first := draw(c, 20)
+	c.Reset()
+	if again := draw(c, 20); !slices.Equal(first, again) {
+		t.Errorf("after Reset() got %q, want %q", again, first)
+	}
+}
+
+func TestBag(t *testing.T) {
+	words := []string{"apple", "banana", "carrot", "date", "elderberry"}
+	bag := api.NewBag(words)
+
+	prev := ""
+	for round := range 20 {
+		got := draw(bag, len(words))
+		if got[0] == prev {
+			t.Errorf("round %d starts with %q, which ended the round before", round, got[0])
+		}
+		prev = got[len(got)-1]
+
+		slices.Sort(got)
+		if !slices.Equal(got, words) {
+			t.Fatalf("round %d = %q, want each of %q once", round, got, words)
+		}
+	}
+}
+
+func TestBag_Single(t *testing.T) {
+	bag := api.NewBag([]string{"apple"})
+	if got := draw(bag, 3); !slices.Equal(got, []string{"apple", "apple", "apple"}) {
+		t.Errorf("draw() = %q, want apple every time", got)
+	}
+}
+
+func TestUnion(t *testing.T) {
+	a := api.NewBag([]string{"a1", "a2", "shared"})
+	b := api.NewBag([]string{"b1", "b2", "b3", "b4", "b5", "b6", "shared"})
+	u := api.Union(a, b, api.NewList(nil))
+
+	want := []string{"a1", "a2", "shared", "b1", "b2", "b3", "b4", "b5", "b6"}
+	if !slices.Equal(u.Words(), want) {
+		t.Fatalf("Words() = %q, want %q", u.Words(), want)
+	}
+	for round := range 20 {
+		got := draw(u, len(want))
+		if sorted := slices.Sorted(slices.Values(got)); !slices.Equal(sorted, slices.Sorted(slices.Values(want))) {
+			t.Fatalf("round %d = %q, want each of %q once", round, got, want)
+		}
+	}
+}
+
+func TestSeed(t *testing.T) {
+	words := []string{"apple", "banana", "carrot", "date", "elderberry", "fig", "grape"}
+	tests := []struct {
+		name   string // description of this test case
+		source func() api.WordSource
+	}{
+		{"TestList", func() api.WordSource { return api.NewList(words) }},
+		{"TestBag", func() api.WordSource { return api.NewBag(words) }},
+		{"TestFilteredBag", func() api.WordSource {
+			return api.NewBag(words).Filter(func(word string) bool { return len(word) > 4 })
+		}},
+		{"TestWeighted", func() api.WordSource { return api.NewWeighted(words, api.EmbeddedFrequency().Weight) }},
+		{"TestUnion", func() api.WordSource {
+			return api.Union(api.NewBag(words[:4]), api.NewBag(words[2:]))
+		}},
+		{"TestComposite", func() api.WordSource {
+			return api.NewComposite(api.NewList(words[:3]), api.NewBag(words[3:]))
+		}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a, b, c := tt.source(), tt.source(), tt.source()
+			if !api.Seed(a, 42) || !api.Seed(b, 42) || !api.Seed(c, 7) {
+				t.Fatalf("Seed() = false, want the source to be a Seeder")
+			}
+
+			first := draw(a, 30)
+			if again := draw(b, 30); !slices.Equal(first, again) {
+				t.Errorf("same seed gave %q and %q", first, again)
+			}
+			if other := draw(c, 30); slices.Equal(first, other) {
+				t.Errorf("seeds 42 and 7 both gave %q", first)
+			}
+		})
+	}
+}
@@ -0,0 +1,169 @@
+package api
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// Entry is a word from a word list, with optional metadata that takes precedence over the dictionary.
+type Entry struct {
+	Word         string   `json:"word"`
+	Definition   string   `json:"definition,omitempty"`
+	Sentence     string   `json:"sentence,omitempty"` // example sentence, e.g. for spelling-bee mode
+	PartOfSpeech string   `json:"part_of_speech,omitempty"`
+	Tags         []string `json:"tags,omitempty"` // for organizing lists; practice doesn't use them
+}
+
+// Format is the file format of a word list.
+type Format string
+
+const (
+	Plain Format = "plain" // one word per line
+	CSV   Format = "csv"   // word,definition,sentence,part_of_speech,tags
+	TSV   Format = "tsv"   // like CSV, separated by tabs
+	JSON  Format = "json"  // an array of Entry objects or of plain strings
+)
+
+// columns are the CSV and TSV columns in order. Only the word is required.
+var columns = []string{"word", "definition", "sentence", "part_of_speech", "tags"}
+
+// FormatOf guesses the format of a word list from its file extension.
+// Anything that isn't .csv, .tsv or .json, including stdin, is read as plain text.
+func FormatOf(path string) Format {
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".csv":
+		return CSV
+	case ".tsv", ".tab":
+		return TSV
+	case ".json":
+		return JSON
+	}
+	return Plain
+}
+
+// LoadEntries reads the word list at path, or stdin if path is "-", in the format its extension suggests.
+func LoadEntries(path string) ([]Entry, error) {
+	if path == "-" {
+		return ReadEntries(os.Stdin, Plain)
+	}
+
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	entries, err := ReadEntries(f, FormatOf(path))
+	if err != nil {
+		return nil, fmt.Errorf("reading word list %s: %w", path, err)
+	}
+	return entries, nil
+}
+
+// ReadEntries reads a word list in the given format. Entries without a word are skipped.
+func ReadEntries(r io.Reader, format Format) ([]Entry, error) {
+	switch format {
+	case Plain:
+		words, err := ReadWords(r)
+		if err != nil {
+			return nil, err
+		}
+		entries := make([]Entry, len(words))
+		for i, word := range words {
+			entries[i] = Entry{Word: word}
+		}
+		return entries, nil
+	case CSV:
+		return readDelimited(r, ',')
+	case TSV:
+		return readDelimited(r, '\t')
+	case JSON:
+		return readJSON(r)
+	}
+	return nil, fmt.Errorf("unknown word list format %q", format)
+}
+
+// readDelimited reads CSV or TSV rows in the order of columns.
+// A first row starting with "word" is taken as a header and skipped.
+// Tags are separated by semicolons or spaces.
+func readDelimited(r io.Reader, comma rune) ([]Entry, error) {
+	reader := csv.NewReader(r)
+	reader.Comma = comma
+	reader.Comment = '#'
+	reader.FieldsPerRecord = -1       // trailing columns are optional
+	reader.LazyQuotes = comma == '\t' // TSV sentences may quote speech without escaping it
+
+	var entries []Entry
+	for line := 0; ; line++ {
+		record, err := reader.Read()
+		if err == io.EOF {
+			return entries, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		if len(record) > len(columns) {
+			row, _ := reader.FieldPos(0)
+			return nil, fmt.Errorf("line %d: %d columns, want at most %d (%s)", row, len(record), len(columns), strings.Join(columns, ", "))
+		}
+		if line == 0 && strings.EqualFold(strings.TrimSpace(record[0]), columns[0]) {
+			continue
+		}
+
+		field := func(i int) string {
+			if i < len(record) {
+				return strings.TrimSpace(record[i])
+			}
+			return ""
+		}
+		entry := Entry{
+			Word:         field(0),
+			Definition:   field(1),
+			Sentence:     field(2),
+			PartOfSpeech: field(3),
+		}
+		if tags := field(4); tags != "" {
+			entry.Tags = strings.FieldsFunc(tags, func(r rune) bool { return r == ';' || r == ' ' })
+		}
+		if entry.Word != "" {
+			entries = append(entries, entry)
+		}
+	}
+}
+
+// readJSON reads an array whose elements are either Entry objects or bare words.
+func readJSON(r io.Reader) ([]Entry, error) {
+	var raw []json.RawMessage
+	if err := json.NewDecoder(r).Decode(&raw); err != nil {
+		return nil, err
+	}
+
+	entries := make([]Entry, 0, len(raw))
+	for i, msg := range raw {
+		var entry Entry
+		if err := json.Unmarshal(msg, &entry.Word); err != nil {
+			if err := json.Unmarshal(msg, &entry); err != nil {
+				return nil, fmt.Errorf("entry %d: %w", i, err)
+			}
+		}
+		entry.Word = strings.TrimSpace(entry.Word)
+		if entry.Word != "" {
+			entries = append(entries, entry)
+		}
+	}
+	return entries, nil
+}
+
+// Words returns the words of entries.
+func Words(entries []Entry) []string {
+	words := make([]string, len(entries))
+	for i, entry := range entries {
+		words[i] = entry.Word
+	}
+	return words
+}
@@ -0,0 +1,90 @@
+package api_test
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/jharlan-hash/gospell/internal/api"
+)
+
+func TestReadEntries(t *testing.T) {
+	tests := []struct {
+		name    string // description of this test case
+		format  api.Format
+		input   string
+		want    []api.Entry
+		wantErr bool
+	}{
+		{"TestPlain", api.Plain, "apple\n\nbanana\n", []api.Entry{{Word: "apple"}, {Word: "banana"}}, false},
+		{"TestCSVWordsOnly", api.CSV, "apple\nbanana\n", []api.Entry{{Word: "apple"}, {Word: "banana"}}, false},
+		{"TestCSVWithHeader", api.CSV, "word,definition\nossify,to turn into bone\n", []api.Entry{
+			{Word: "ossify", Definition: "to turn into bone"},
+		}, false},
+		{"TestCSVAllColumns", api.CSV, `idempotent,"having the same effect, however often applied","PUT is idempotent.",adjective,cs;math` + "\n", []api.Entry{
+			{Word: "idempotent", Definition: "having the same effect, however often applied", Sentence: "PUT is idempotent.", PartOfSpeech: "adjective", Tags: []string{"cs", "math"}},
+		}, false},
+		{"TestCSVSkipsComments", api.CSV, "# week 3\napple\n", []api.Entry{{Word: "apple"}}, false},
+		{"TestCSVTooManyColumns", api.CSV, "a,b,c,d,e,f\n", nil, true},
+		{"TestTSV", api.TSV, "word\tdefinition\tsentence\nquay\ta landing place\tThe boat \"docked\" at the quay.\n", []api.Entry{
+			{Word: "quay", Definition: "a landing place", Sentence: `The boat "docked" at the quay.`},
+		}, false},
+		{"TestJSONObjects", api.JSON, `[{"word": "syzygy", "part_of_speech": "noun", "tags": ["bee"]}]`, []api.Entry{
+			{Word: "syzygy", PartOfSpeech: "noun", Tags: []string{"bee"}},
+		}, false},
+		{"TestJSONMixed", api.JSON, `["apple", {"word": "banana", "definition": "a yellow fruit"}, ""]`, []api.Entry{
+			{Word: "apple"}, {Word: "banana", Definition: "a yellow fruit"},
+		}, false},
+		{"TestJSONNotAnArray", api.JSON, `{"word": "apple"}`, nil, true},
+		{"TestUnknownFormat", api.Format("xml"), "<apple/>", nil, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := api.ReadEntries(strings.NewReader(tt.input), tt.format)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ReadEntries() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !tt.wantErr && !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ReadEntries() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFormatOf(t *testing.T) {
+	tests := []struct {
+		path string
+		want api.Format
+	}{
+		{"vocab.csv", api.CSV},
+		{"jargon.TSV", api.TSV},
+		{"bee/study-guide.json", api.JSON},
+		{"words.txt", api.Plain},
+		{"-", api.Plain},
+	}
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			if got := api.FormatOf(tt.path); got != tt.want {
+				t.Errorf("FormatOf(%q) = %v, want %v", tt.path, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestLoadEntries(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "vocab.csv")
+	if err := os.WriteFile(path, []byte("apple,a fruit\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := api.LoadEntries(path)
+	if err != nil {
+		t.Fatalf("LoadEntries() error = %v", err)
+	}
+	want := []api.Entry{{Word: "apple", Definition: "a fruit"}}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("LoadEntries() = %+v, want %+v", got, want)
+	}
+}
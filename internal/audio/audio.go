@@ -0,0 +1,92 @@
+// Package audio defines how gospell plays synthesized speech.
+// The sound card implementation lives in package speaker.
+package audio
+
+import (
+	"bytes"
+	"errors"
+
+	"github.com/gopxl/beep"
+	"github.com/gopxl/beep/mp3"
+	"github.com/gopxl/beep/wav"
+)
+
+const (
+	// MinVolume is the lowest volume level; at this level playback is muted.
+	MinVolume = -8
+	// MaxVolume is the highest volume level.
+	MaxVolume = 4
+)
+
+// Player plays audio clips one at a time.
+// Clips are encoded audio files as returned by a tts.Synthesizer: WAV, MP3 or Ogg Opus.
+type Player interface {
+	// Play stops whatever is playing and plays clip at speed,
+	// where 1 is normal speed and 0.5 half speed.
+	Play(clip []byte, speed float64) error
+	// AdjustVolume changes the volume by delta levels and returns the new level,
+	// clamped to [MinVolume, MaxVolume]. Level 0 is the clip's own volume.
+	AdjustVolume(delta int) int
+}
+
+var (
+	// ErrEmptyClip is returned when asked to play a clip without any audio.
+	ErrEmptyClip = errors.New("empty audio clip")
+	// ErrUnknownFormat is returned for clips that are not WAV, MP3 or Ogg Opus.
+	ErrUnknownFormat = errors.New("unknown audio format")
+)
+
+// Decode decodes a WAV, MP3 or Ogg Opus clip into a streamer.
+// The format is detected from the clip's first bytes.
+func Decode(clip []byte) (beep.StreamSeekCloser, beep.Format, error) {
+	switch {
+	case len(clip) == 0:
+		return nil, beep.Format{}, ErrEmptyClip
+	case bytes.HasPrefix(clip, []byte("RIFF")):
+		return wav.Decode(bytes.NewReader(clip))
+	case bytes.HasPrefix(clip, []byte("OggS")):
+		return decodeOpus(clip)
+	case isMP3(clip):
+		return mp3.Decode(nopCloser{bytes.NewReader(clip)})
+	}
+	return nil, beep.Format{}, ErrUnknownFormat
+}
+
+// nopCloser keeps the clip reader seekable, which the MP3 decoder needs to know the clip's length.
+type nopCloser struct {
+	*bytes.Reader
+}
+
+func (nopCloser) Close() error { return nil }
+
+// isMP3 reports whether clip starts with an ID3 tag or an MPEG audio frame sync.
+func isMP3(clip []byte) bool {
+	if bytes.HasPrefix(clip, []byte("ID3")) {
+		return true
+	}
+	return len(clip) >= 2 && clip[0] == 0xFF && clip[1]&0xE0 == 0xE0
+}
+
+// Discard is a Player that plays nothing, for running without a sound card.
+// It still decodes clips, so broken audio is reported the same way as by a real player.
+type Discard struct {
+	volume int
+}
+
+func (d *Discard) Play(clip []byte, speed float64) error {
+	s, _, err := Decode(clip)
+	if err != nil {
+		return err
+	}
+	return s.Close()
+}
+
+func (d *Discard) AdjustVolume(delta int) int {
+	d.volume = ClampVolume(d.volume + delta)
+	return d.volume
+}
+
+// ClampVolume limits a volume level to [MinVolume, MaxVolume].
+func ClampVolume(level int) int {
+	return min(max(level, MinVolume), MaxVolume)
+}
@@ -0,0 +1,120 @@
+package audio_test
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/jharlan-hash/gospell/internal/audio"
+)
+
+// wavClip builds a mono 16-bit PCM WAV clip of silence.
+func wavClip(sampleRate, samples int) []byte {
+	return toneClip(sampleRate, samples, 0)
+}
+
+// toneClip returns a mono 16-bit WAV clip whose samples are all level.
+func toneClip(sampleRate, samples int, level int16) []byte {
+	var buf bytes.Buffer
+	dataSize := samples * 2
+	buf.WriteString("RIFF")
+	binary.Write(&buf, binary.LittleEndian, uint32(36+dataSize))
+	buf.WriteString("WAVEfmt ")
+	binary.Write(&buf, binary.LittleEndian, uint32(16))           // fmt chunk size
+	binary.Write(&buf, binary.LittleEndian, uint16(1))            // PCM
+	binary.Write(&buf, binary.LittleEndian, uint16(1))            // mono
+	binary.Write(&buf, binary.LittleEndian, uint32(sampleRate))   // sample rate
+	binary.Write(&buf, binary.LittleEndian, uint32(sampleRate*2)) // byte rate
+	binary.Write(&buf, binary.LittleEndian, uint16(2))            // block align
+	binary.Write(&buf, binary.LittleEndian, uint16(16))           // bits per sample
+	buf.WriteString("data")
+	binary.Write(&buf, binary.LittleEndian, uint32(dataSize))
+	for range samples {
+		binary.Write(&buf, binary.LittleEndian, level)
+	}
+	return buf.Bytes()
+}
+
+// readClip reads a clip from testdata.
+// tiny.ogg comes from github.com/pion/opus and tone.mp3 from github.com/gopxl/beep, both MIT licensed.
+func readClip(t *testing.T, name string) []byte {
+	t.Helper()
+	clip, err := os.ReadFile(filepath.Join("testdata", name))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return clip
+}
+
+func TestDecode(t *testing.T) {
+	tests := []struct {
+		name     string // description of this test case
+		clip     []byte
+		wantRate int
+		wantErr  bool
+	}{
+		{"TestWav", wavClip(24000, 2400), 24000, false},
+		{"TestMP3", readClip(t, "tone.mp3"), 44100, false},
+		{"TestOggOpus", readClip(t, "tiny.ogg"), 48000, false},
+		{"TestTruncatedOgg", readClip(t, "tiny.ogg")[:40], 0, true},
+		{"TestEmpty", nil, 0, true},
+		{"TestGarbage", []byte("definitely not audio"), 0, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s, format, err := audio.Decode(tt.clip)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Decode() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err != nil {
+				return
+			}
+			defer s.Close()
+
+			if int(format.SampleRate) != tt.wantRate {
+				t.Errorf("Decode() sample rate = %d, want %d", format.SampleRate, tt.wantRate)
+			}
+			if s.Len() == 0 {
+				t.Errorf("Decode() returned no samples")
+			}
+		})
+	}
+}
+
+func TestDiscard_Play(t *testing.T) {
+	var d audio.Discard
+	if err := d.Play(wavClip(24000, 10), 1); err != nil {
+		t.Errorf("Play() error = %v", err)
+	}
+	if err := d.Play(nil, 1); !errors.Is(err, audio.ErrEmptyClip) {
+		t.Errorf("Play(nil) error = %v, want ErrEmptyClip", err)
+	}
+}
+
+func TestDiscard_AdjustVolume(t *testing.T) {
+	tests := []struct {
+		name   string // description of this test case
+		deltas []int
+		want   int
+	}{
+		{"TestUp", []int{1, 1}, 2},
+		{"TestUpAndDown", []int{1, -1, -1}, -1},
+		{"TestClampsHigh", []int{100}, audio.MaxVolume},
+		{"TestClampsLow", []int{-100}, audio.MinVolume},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var d audio.Discard
+			var got int
+			for _, delta := range tt.deltas {
+				got = d.AdjustVolume(delta)
+			}
+			if got != tt.want {
+				t.Errorf("AdjustVolume() = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,90 @@
+package audio
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/gopxl/beep"
+	"github.com/pion/opus"
+	"github.com/pion/opus/pkg/oggreader"
+)
+
+// opusSampleRate is the rate Opus is decoded at; Ogg Opus always runs at 48 kHz internally.
+const opusSampleRate = 48000
+
+// maxOpusFrame is the most samples per channel one Opus packet can hold (120 ms at 48 kHz).
+const maxOpusFrame = 5760
+
+// decodeOpus decodes an Ogg Opus clip. Clips are short, so the whole clip is decoded up front.
+func decodeOpus(clip []byte) (beep.StreamSeekCloser, beep.Format, error) {
+	ogg, header, err := oggreader.NewWith(bytes.NewReader(clip))
+	if err != nil {
+		return nil, beep.Format{}, fmt.Errorf("ogg: %w", err)
+	}
+
+	channels := int(header.Channels)
+	decoder, err := opus.NewDecoderWithOutput(opusSampleRate, channels)
+	if err != nil {
+		return nil, beep.Format{}, fmt.Errorf("opus: %w", err)
+	}
+
+	var samples [][2]float64
+	frame := make([]float32, maxOpusFrame*channels)
+	for {
+		packet, _, err := ogg.ParseNextPacket()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, beep.Format{}, fmt.Errorf("ogg: %w", err)
+		}
+		if bytes.HasPrefix(packet, []byte("OpusTags")) {
+			continue
+		}
+
+		n, err := decoder.DecodeToFloat32(packet, frame)
+		if err != nil {
+			return nil, beep.Format{}, fmt.Errorf("opus: %w", err)
+		}
+		for i := range n {
+			left := float64(frame[i*channels])
+			right := float64(frame[i*channels+channels-1])
+			samples = append(samples, [2]float64{left, right})
+		}
+	}
+
+	// the encoder's start-up samples aren't part of the audio
+	skip := min(int(header.PreSkip), len(samples))
+	format := beep.Format{SampleRate: opusSampleRate, NumChannels: channels, Precision: 2}
+	return &sampleStreamer{samples: samples[skip:]}, format, nil
+}
+
+// sampleStreamer streams samples decoded ahead of time.
+type sampleStreamer struct {
+	samples [][2]float64
+	pos     int
+}
+
+func (s *sampleStreamer) Stream(samples [][2]float64) (n int, ok bool) {
+	if s.pos >= len(s.samples) {
+		return 0, false
+	}
+	n = copy(samples, s.samples[s.pos:])
+	s.pos += n
+	return n, true
+}
+
+func (s *sampleStreamer) Err() error    { return nil }
+func (s *sampleStreamer) Len() int      { return len(s.samples) }
+func (s *sampleStreamer) Position() int { return s.pos }
+func (s *sampleStreamer) Close() error  { return nil }
+
+func (s *sampleStreamer) Seek(p int) error {
+	if p < 0 || p > len(s.samples) {
+		return fmt.Errorf("seek position %d out of range [0, %d]", p, len(s.samples))
+	}
+	s.pos = p
+	return nil
+}
@@ -0,0 +1,188 @@
+package audio
+
+import (
+	"errors"
+	"io"
+	"sync"
+
+	"github.com/gopxl/beep"
+	"github.com/gopxl/beep/effects"
+)
+
+// Output is a sound device that mixes the streamers it plays, like the sound card of package speaker.
+// It reads them on a goroutine of its own, which Lock keeps out while a streamer is changed.
+type Output interface {
+	Play(s ...beep.Streamer)
+	Clear()
+	Lock()
+	Unlock()
+}
+
+// ErrClosed is returned when playing on an OutputPlayer that was closed, e.g. by a word that finished synthesizing after quitting.
+var ErrClosed = errors.New("speaker closed")
+
+// OutputPlayer is a Player that plays on an Output running at a fixed sample rate; clips are resampled to it.
+// It owns the output from a single goroutine, so new clips cleanly interrupt old ones instead of talking over them.
+type OutputPlayer struct {
+	out       Output
+	rate      beep.SampleRate
+	cmds      chan command
+	quit      chan struct{} // closed by Close; cmds is never closed, as other goroutines may still be sending
+	closeOnce sync.Once
+	done      chan struct{}
+
+	mu     sync.Mutex
+	volume int
+}
+
+type commandKind int
+
+const (
+	cmdPlay commandKind = iota
+	cmdVolume
+)
+
+type command struct {
+	kind   commandKind
+	stream beep.Streamer
+	closer io.Closer // the decoder behind stream, closed once it stops playing
+}
+
+// NewOutputPlayer starts the player goroutine for out, which runs at rate. Call Close to stop it.
+func NewOutputPlayer(out Output, rate beep.SampleRate) *OutputPlayer {
+	p := &OutputPlayer{
+		out:  out,
+		rate: rate,
+		cmds: make(chan command),
+		quit: make(chan struct{}),
+		done: make(chan struct{}),
+	}
+	go p.run()
+	return p
+}
+
+// Play decodes clip on the caller's goroutine, so errors are reported to the caller,
+// and hands the stream to the player goroutine, which interrupts whatever is playing.
+func (p *OutputPlayer) Play(clip []byte, speed float64) error {
+	streamer, format, err := Decode(clip)
+	if err != nil {
+		return err
+	}
+
+	ratio := float64(format.SampleRate) / float64(p.rate) * speed
+	var stream beep.Streamer = streamer
+	if ratio != 1 {
+		stream = beep.ResampleRatio(4, ratio, streamer)
+	}
+
+	if err := p.command(command{kind: cmdPlay, stream: stream, closer: streamer}); err != nil {
+		streamer.Close()
+		return err
+	}
+	return nil
+}
+
+func (p *OutputPlayer) AdjustVolume(delta int) int {
+	p.mu.Lock()
+	p.volume = ClampVolume(p.volume + delta)
+	level := p.volume
+	p.mu.Unlock()
+
+	p.command(command{kind: cmdVolume})
+	return level
+}
+
+// Close stops playback and the player goroutine. Playing afterwards returns ErrClosed.
+func (p *OutputPlayer) Close() {
+	p.closeOnce.Do(func() { close(p.quit) })
+	<-p.done
+}
+
+// command hands cmd to the player goroutine, unless the player is closed.
+func (p *OutputPlayer) command(cmd command) error {
+	select {
+	case p.cmds <- cmd:
+		return nil
+	case <-p.quit:
+		return ErrClosed
+	}
+}
+
+// run is the player goroutine. It plays one stream at a time.
+func (p *OutputPlayer) run() {
+	defer close(p.done)
+
+	var (
+		current  *effects.Volume
+		closer   io.Closer        // the decoder of the current stream
+		gen      int              // identifies the current stream, so stale callbacks are ignored
+		finished = make(chan int) // receives the gen of a stream that played to the end
+	)
+
+	start := func(cmd command) {
+		gen++
+		g := gen
+		current, closer = &effects.Volume{Streamer: cmd.stream, Base: 2}, cmd.closer
+		p.applyVolume(current)
+		p.out.Play(beep.Seq(current, beep.Callback(func() {
+			// the callback runs on the output's goroutine; don't block it
+			go func() {
+				select {
+				case finished <- g:
+				case <-p.done:
+				}
+			}()
+		})))
+	}
+
+	// release closes the decoder of the current stream, once the output no longer reads it.
+	release := func() {
+		if closer != nil {
+			closer.Close()
+		}
+		current, closer = nil, nil
+	}
+
+	stop := func() {
+		p.out.Clear()
+		release()
+	}
+
+	for {
+		select {
+		case <-p.quit:
+			stop()
+			return
+
+		case cmd := <-p.cmds:
+			switch cmd.kind {
+			case cmdPlay:
+				stop()
+				start(cmd)
+			case cmdVolume:
+				if current != nil {
+					p.out.Lock()
+					p.applyVolume(current)
+					p.out.Unlock()
+				}
+			}
+
+		case g := <-finished:
+			if g != gen || current == nil {
+				continue // a stream we already stopped
+			}
+			release()
+		}
+	}
+}
+
+// applyVolume sets v to the current volume level.
+// Each level is half a power of two, about 3 dB.
+func (p *OutputPlayer) applyVolume(v *effects.Volume) {
+	p.mu.Lock()
+	level := p.volume
+	p.mu.Unlock()
+
+	v.Volume = float64(level) / 2
+	v.Silent = level <= MinVolume
+}
@@ -0,0 +1,160 @@
+package audio_test
+
+import (
+	"errors"
+	"math"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/gopxl/beep"
+	"github.com/jharlan-hash/gospell/internal/audio"
+)
+
+// rate is the sample rate of the output and the clips, so they play as decoded: a mono sample of 1<<13 is 0.125 on each channel.
+const rate = 48000
+
+// fakeOutput is an audio.Output that mixes its streamers only when the test reads a sample, instead of on a sound card.
+type fakeOutput struct {
+	mu sync.Mutex // held while streaming, as Lock holds it
+
+	state   sync.Mutex
+	playing []beep.Streamer
+}
+
+func (o *fakeOutput) Play(s ...beep.Streamer) {
+	o.state.Lock()
+	defer o.state.Unlock()
+	o.playing = append(o.playing, beep.Seq(s...))
+}
+
+func (o *fakeOutput) Clear() {
+	o.state.Lock()
+	defer o.state.Unlock()
+	o.playing = nil
+}
+
+func (o *fakeOutput) Lock()   { o.mu.Lock() }
+func (o *fakeOutput) Unlock() { o.mu.Unlock() }
+
+// sample mixes the next sample of everything playing, dropping the streamers that ran out, and returns its left channel.
+func (o *fakeOutput) sample() (value float64, playing int) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	o.state.Lock()
+	defer o.state.Unlock()
+
+	var still []beep.Streamer
+	for _, s := range o.playing {
+		buf := make([][2]float64, 1)
+		if n, ok := s.Stream(buf); n == 1 && ok {
+			value += buf[0][0]
+			still = append(still, s)
+		}
+	}
+	o.playing = still
+	return value, len(still)
+}
+
+// waitFor reads samples from o until one is want with a single stream playing, failing the test if none is within a second.
+func waitFor(t *testing.T, o *fakeOutput, want float64) {
+	t.Helper()
+	var got float64
+	var playing int
+	for deadline := time.Now().Add(time.Second); time.Now().Before(deadline); time.Sleep(time.Millisecond) {
+		got, playing = o.sample()
+		if math.Abs(got-want) < 1e-3 && playing == 1 {
+			return
+		}
+	}
+	t.Fatalf("sample = %v with %d streams playing, want %v from one", got, playing, want)
+}
+
+func TestOutputPlayer_PlayInterrupts(t *testing.T) {
+	out := &fakeOutput{}
+	p := audio.NewOutputPlayer(out, rate)
+	defer p.Close()
+
+	if err := p.Play(toneClip(rate, rate, 1<<13), 1); err != nil {
+		t.Fatalf("Play: %v", err)
+	}
+	waitFor(t, out, 0.125)
+
+	if err := p.Play(toneClip(rate, rate, -1<<13), 1); err != nil {
+		t.Fatalf("Play: %v", err)
+	}
+	waitFor(t, out, -0.125)
+
+	if err := p.Play([]byte("not audio"), 1); err == nil {
+		t.Error("Play of a broken clip succeeded, want an error")
+	}
+	waitFor(t, out, -0.125) // a broken clip doesn't interrupt the one playing
+}
+
+func TestOutputPlayer_PlayAfterEnd(t *testing.T) {
+	out := &fakeOutput{}
+	p := audio.NewOutputPlayer(out, rate)
+	defer p.Close()
+
+	if err := p.Play(toneClip(rate, 1, 1<<13), 1); err != nil {
+		t.Fatalf("Play: %v", err)
+	}
+	waitFor(t, out, 0.125)
+	if _, playing := out.sample(); playing != 0 {
+		t.Fatalf("%d streams playing after the clip ended, want 0", playing)
+	}
+
+	if err := p.Play(toneClip(rate, rate, 1<<14), 1); err != nil {
+		t.Fatalf("Play: %v", err)
+	}
+	waitFor(t, out, 0.25)
+}
+
+func TestOutputPlayer_AdjustVolume(t *testing.T) {
+	out := &fakeOutput{}
+	p := audio.NewOutputPlayer(out, rate)
+	defer p.Close()
+
+	if err := p.Play(toneClip(rate, rate, 1<<13), 1); err != nil {
+		t.Fatalf("Play: %v", err)
+	}
+	waitFor(t, out, 0.125)
+
+	if level := p.AdjustVolume(-2); level != -2 {
+		t.Errorf("AdjustVolume(-2) = %d, want -2", level)
+	}
+	waitFor(t, out, 0.0625) // two levels down halves the volume of the clip already playing
+
+	if level := p.AdjustVolume(-100); level != audio.MinVolume {
+		t.Errorf("AdjustVolume(-100) = %d, want %d", level, audio.MinVolume)
+	}
+	waitFor(t, out, 0) // the lowest level is silent
+
+	if level := p.AdjustVolume(audio.MaxVolume - audio.MinVolume); level != audio.MaxVolume {
+		t.Errorf("AdjustVolume to the top = %d, want %d", level, audio.MaxVolume)
+	}
+	if err := p.Play(toneClip(rate, rate, 1<<11), 1); err != nil {
+		t.Fatalf("Play: %v", err)
+	}
+	waitFor(t, out, 0.125) // new clips play at the current volume
+}
+
+func TestOutputPlayer_Close(t *testing.T) {
+	out := &fakeOutput{}
+	p := audio.NewOutputPlayer(out, rate)
+
+	if err := p.Play(toneClip(rate, rate, 1<<13), 1); err != nil {
+		t.Fatalf("Play: %v", err)
+	}
+	waitFor(t, out, 0.125)
+
+	p.Close()
+	if _, playing := out.sample(); playing != 0 {
+		t.Errorf("%d streams playing after Close, want 0", playing)
+	}
+	if err := p.Play(toneClip(rate, rate, 1<<13), 1); !errors.Is(err, audio.ErrClosed) {
+		t.Errorf("Play after Close = %v, want ErrClosed", err)
+	}
+	p.AdjustVolume(1) // doesn't block
+	p.Close()         // nor does closing twice
+}
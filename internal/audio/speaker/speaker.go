@@ -0,0 +1,41 @@
+// Package speaker plays audio through the sound card.
+// It is kept apart from package audio so that code using an audio.Player
+// can be built and tested on machines without one.
+package speaker
+
+import (
+	"time"
+
+	"github.com/gopxl/beep"
+	"github.com/gopxl/beep/speaker"
+
+	"github.com/jharlan-hash/gospell/internal/audio"
+)
+
+// SampleRate is the rate the speaker runs at; clips are resampled to it.
+const SampleRate beep.SampleRate = 48000
+
+// ErrClosed is returned when playing on a Speaker that was closed, e.g. by a word that finished synthesizing after quitting.
+var ErrClosed = audio.ErrClosed
+
+// Speaker is an audio.Player that plays through the sound card, one clip at a time, see audio.OutputPlayer.
+type Speaker struct {
+	*audio.OutputPlayer
+}
+
+// New initializes the sound card and starts the player goroutine.
+// Call Close to stop it.
+func New() (*Speaker, error) {
+	if err := speaker.Init(SampleRate, SampleRate.N(time.Second/10)); err != nil {
+		return nil, err
+	}
+	return &Speaker{audio.NewOutputPlayer(card{}, SampleRate)}, nil
+}
+
+// card is the sound card as an audio.Output, once speaker.Init has set it up.
+type card struct{}
+
+func (card) Play(s ...beep.Streamer) { speaker.Play(s...) }
+func (card) Clear()                  { speaker.Clear() }
+func (card) Lock()                   { speaker.Lock() }
+func (card) Unlock()                 { speaker.Unlock() }
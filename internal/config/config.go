@@ -0,0 +1,129 @@
+package config
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/jharlan-hash/gospell/internal/api"
+	"github.com/jharlan-hash/gospell/internal/definition"
+	"github.com/jharlan-hash/gospell/internal/difficulty"
+	"github.com/jharlan-hash/gospell/internal/homophone"
+	"github.com/jharlan-hash/gospell/internal/review"
+	"github.com/jharlan-hash/gospell/internal/tts"
+)
+
+// Config holds the user's settings.
+// It is read from a JSON config file, and command-line flags override individual fields.
+type Config struct {
+	Credentials string    `json:"credentials"` // path to a Google Cloud credentials JSON file
+	Endpoint    string    `json:"endpoint"`    // custom TextToSpeech API endpoint, e.g. a local stand-in
+	Silent      bool      `json:"silent"`      // run without text-to-speech
+	NoCache     bool      `json:"no_cache"`    // don't use the on-disk caches of audio and looked-up definitions
+	Prefetch    int       `json:"prefetch"`    // number of upcoming words to synthesize ahead of time
+	Voice       tts.Voice `json:"voice"`
+
+	Wordlists         []string `json:"wordlists"`           // word list files to practice from instead of the embedded list, "-" for stdin
+	Packs             []string `json:"packs"`               // names of word packs to practice from instead of the embedded list, see pack.All
+	Seed              *int64   `json:"seed"`                // makes the sequence of words repeatable; nil picks a random one
+	Shuffle           bool     `json:"shuffle"`             // never repeat a word until every word of the list came up
+	Band              string   `json:"band"`                // frequency band to practice: common, uncommon or rare; empty for all words
+	WeightByFrequency bool     `json:"weight_by_frequency"` // pick frequent words more often than rare ones
+	Drill             bool     `json:"drill"`               // drill inflection families in a row, see morphology.Drill
+	Homophones        string   `json:"homophones"`          // how to handle words that sound like others: define, accept or skip
+	Level             int      `json:"level"`               // difficulty level from 1 to 5, see difficulty.Levels; 0 uses MinDifficulty and MaxDifficulty
+	MinDifficulty     float64  `json:"min_difficulty"`      // easiest difficulty score to practice, 0 to 100
+	MaxDifficulty     float64  `json:"max_difficulty"`      // hardest difficulty score to practice, 0 to 100
+
+	NoReview    bool    `json:"no_review"`    // don't record attempts or review missed words
+	ReviewFile  string  `json:"review_file"`  // where the review schedule is kept, see review.DefaultPath
+	ReviewRatio float64 `json:"review_ratio"` // share of words that are due reviews, 0 to 1
+
+	Online             bool   `json:"online"`              // look words the dictionaries lack up online, in DefinitionsURL
+	DefinitionsURL     string `json:"definitions_url"`     // dictionaryapi.dev-compatible API for Online; empty uses definition.DefaultBaseURL
+	DefinitionsFile    string `json:"definitions_file"`    // the user's own definitions, see definition.LoadOverlay
+	MissingDefinitions string `json:"missing_definitions"` // what to do with words without definitions: show, skip, fallback or sentence
+	NoMask             bool   `json:"no_mask"`             // show definitions as they are, even where they contain the word, see definition.Mask
+
+	SpeakSentences  bool   `json:"speak_sentences"`   // read example sentences aloud when they are shown
+	Bee             bool   `json:"bee"`               // speak words spelling-bee style: word, sentence, word
+	BeeTemplateFile string `json:"bee_template_file"` // custom SSML template for Bee, see tts.ParseTemplate
+}
+
+// Default returns the settings used when neither the config file nor a flag sets a field.
+func Default() Config {
+	return Config{
+		Prefetch:      3,
+		Voice:         tts.DefaultVoice,
+		MaxDifficulty: difficulty.MaxScore,
+		ReviewRatio:   review.DefaultRatio,
+		Homophones:    string(homophone.Define),
+
+		MissingDefinitions: string(definition.Show),
+	}
+}
+
+// DefaultPath returns where the config file lives unless --config says otherwise,
+// e.g. $XDG_CONFIG_HOME/gospell/config.json on Linux.
+func DefaultPath() (string, error) {
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "gospell", "config.json"), nil
+}
+
+// Load reads the config file at path into c.
+// Fields missing from the file keep their current values, so a file only needs the settings it changes.
+func (c *Config) Load(path string) error {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+	if err := json.Unmarshal(b, c); err != nil {
+		return fmt.Errorf("parsing config file %s: %w", path, err)
+	}
+	return nil
+}
+
+// Validate reports whether the settings make sense together.
+func (c *Config) Validate() error {
+	if c.Prefetch < 0 {
+		return fmt.Errorf("prefetch must not be negative, got %d", c.Prefetch)
+	}
+	if c.Level < 0 || c.Level > len(difficulty.Levels) {
+		return fmt.Errorf("level must be between 1 and %d, got %d", len(difficulty.Levels), c.Level)
+	}
+	if c.MinDifficulty < 0 || c.MaxDifficulty > difficulty.MaxScore || c.MinDifficulty > c.MaxDifficulty {
+		return fmt.Errorf("difficulty must be between 0 and %d with min <= max, got %g to %g", difficulty.MaxScore, c.MinDifficulty, c.MaxDifficulty)
+	}
+	if _, err := api.ParseBand(c.Band); err != nil {
+		return err
+	}
+	if _, err := homophone.ParseMode(c.Homophones); err != nil {
+		return err
+	}
+	if _, err := definition.ParseMissing(c.MissingDefinitions); err != nil {
+		return err
+	}
+	if c.Shuffle && c.WeightByFrequency {
+		return errors.New("shuffle and weight_by_frequency can't be combined: a shuffle bag picks every word equally often")
+	}
+	if c.ReviewRatio < 0 || c.ReviewRatio > 1 {
+		return fmt.Errorf("review ratio must be between 0 and 1, got %g", c.ReviewRatio)
+	}
+	if err := c.Voice.Validate(); err != nil {
+		return fmt.Errorf("voice: %w", err)
+	}
+	return nil
+}
+
+// Difficulty returns the range of difficulty scores to practice, from Level if it is set.
+func (c *Config) Difficulty() difficulty.Range {
+	if r, ok := difficulty.Level(c.Level); ok {
+		return r
+	}
+	return difficulty.Range{Min: c.MinDifficulty, Max: c.MaxDifficulty}
+}
@@ -0,0 +1,120 @@
+package config_test
+
+import (
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/jharlan-hash/gospell/internal/config"
+	"github.com/jharlan-hash/gospell/internal/difficulty"
+	"github.com/jharlan-hash/gospell/internal/tts"
+)
+
+func TestConfig_Load(t *testing.T) {
+	tests := []struct {
+		name string // description of this test case
+		file string
+		want func(*config.Config)
+	}{
+		{"TestEmptyFileKeepsDefaults", `{}`, func(c *config.Config) {}},
+		{"TestVoiceNameOnly", `{"voice": {"name": "en-GB-Wavenet-B"}}`, func(c *config.Config) { c.Voice.Name = "en-GB-Wavenet-B" }},
+		{"TestSpeakingRateAndPrefetch", `{"prefetch": 5, "voice": {"speaking_rate": 0.8}}`, func(c *config.Config) {
+			c.Prefetch = 5
+			c.Voice.SpeakingRate = 0.8
+		}},
+		{"TestWordlists", `{"wordlists": ["vocab.csv", "jargon.txt"]}`, func(c *config.Config) { c.Wordlists = []string{"vocab.csv", "jargon.txt"} }},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "config.json")
+			if err := os.WriteFile(path, []byte(tt.file), 0o644); err != nil {
+				t.Fatal(err)
+			}
+
+			got := config.Default()
+			if err := got.Load(path); err != nil {
+				t.Fatalf("Load() error = %v", err)
+			}
+
+			want := config.Default()
+			tt.want(&want)
+			if !reflect.DeepEqual(got, want) {
+				t.Errorf("Load() = %+v, want %+v", got, want)
+			}
+		})
+	}
+}
+
+func TestConfig_LoadMissingFile(t *testing.T) {
+	c := config.Default()
+	err := c.Load(filepath.Join(t.TempDir(), "missing.json"))
+	if !errors.Is(err, fs.ErrNotExist) {
+		t.Errorf("Load() error = %v, want fs.ErrNotExist", err)
+	}
+}
+
+func TestConfig_Validate(t *testing.T) {
+	tests := []struct {
+		name    string // description of this test case
+		modify  func(*config.Config)
+		wantErr bool
+	}{
+		{"TestDefaults", func(c *config.Config) {}, false},
+		{"TestSlowVoice", func(c *config.Config) { c.Voice.SpeakingRate = 0.5 }, false},
+		{"TestRateTooLow", func(c *config.Config) { c.Voice.SpeakingRate = 0.1 }, true},
+		{"TestPitchTooHigh", func(c *config.Config) { c.Voice.Pitch = 25 }, true},
+		{"TestVolumeTooLoud", func(c *config.Config) { c.Voice.VolumeGainDb = 20 }, true},
+		{"TestUnknownGender", func(c *config.Config) { c.Voice.Gender = "ROBOT" }, true},
+		{"TestNegativePrefetch", func(c *config.Config) { c.Prefetch = -1 }, true},
+		{"TestOggOpus", func(c *config.Config) { c.Voice.Encoding = tts.OggOpus }, false},
+		{"TestUnknownEncoding", func(c *config.Config) { c.Voice.Encoding = "FLAC" }, true},
+		{"TestLevel", func(c *config.Config) { c.Level = 5 }, false},
+		{"TestLevelTooHigh", func(c *config.Config) { c.Level = 6 }, true},
+		{"TestDifficultyRange", func(c *config.Config) { c.MinDifficulty, c.MaxDifficulty = 20, 40 }, false},
+		{"TestDifficultyRangeReversed", func(c *config.Config) { c.MinDifficulty, c.MaxDifficulty = 40, 20 }, true},
+		{"TestDifficultyTooHigh", func(c *config.Config) { c.MaxDifficulty = 150 }, true},
+		{"TestReviewsOnly", func(c *config.Config) { c.ReviewRatio = 1 }, false},
+		{"TestReviewRatioTooHigh", func(c *config.Config) { c.ReviewRatio = 1.5 }, true},
+		{"TestCommonBand", func(c *config.Config) { c.Band = "common" }, false},
+		{"TestUnknownBand", func(c *config.Config) { c.Band = "obscure" }, true},
+		{"TestAcceptHomophones", func(c *config.Config) { c.Homophones = "accept" }, false},
+		{"TestUnknownHomophoneMode", func(c *config.Config) { c.Homophones = "guess" }, true},
+		{"TestSkipMissingDefinitions", func(c *config.Config) { c.MissingDefinitions = "skip" }, false},
+		{"TestUnknownMissingDefinitionsMode", func(c *config.Config) { c.MissingDefinitions = "guess" }, true},
+		{"TestShuffleAndWeight", func(c *config.Config) { c.Shuffle, c.WeightByFrequency = true, true }, true},
+		{"TestAnyGender", func(c *config.Config) { c.Voice = tts.Voice{SpeakingRate: 1, Encoding: tts.Linear16} }, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := config.Default()
+			tt.modify(&c)
+			if err := c.Validate(); (err != nil) != tt.wantErr {
+				t.Errorf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestConfig_Difficulty(t *testing.T) {
+	tests := []struct {
+		name   string // description of this test case
+		modify func(*config.Config)
+		want   difficulty.Range
+	}{
+		{"TestDefaultsToAll", func(c *config.Config) {}, difficulty.All},
+		{"TestMinMax", func(c *config.Config) { c.MinDifficulty, c.MaxDifficulty = 20, 40 }, difficulty.Range{Min: 20, Max: 40}},
+		{"TestLevelWins", func(c *config.Config) { c.Level, c.MinDifficulty = 1, 20 }, difficulty.Levels[0]},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := config.Default()
+			tt.modify(&c)
+			if got := c.Difficulty(); got != tt.want {
+				t.Errorf("Difficulty() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
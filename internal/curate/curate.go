@@ -0,0 +1,137 @@
+// Package curate cleans up word lists for spelling practice.
+// It drops blank lines, duplicates, acronyms, profanity and words the dictionary can't define,
+// and reports each word it removed and why.
+package curate
+
+import (
+	"bufio"
+	_ "embed"
+	"io"
+	"strings"
+
+	"github.com/jharlan-hash/gospell/internal/dictionary"
+)
+
+// Reason says why a word was removed from a list.
+type Reason string
+
+const (
+	Blank     Reason = "blank line"
+	Duplicate Reason = "duplicate"
+	Acronym   Reason = "acronym or abbreviation"
+	Profanity Reason = "profanity"
+	Undefined Reason = "no definition"
+)
+
+// Reasons lists every Reason in the order Lint checks them.
+var Reasons = []Reason{Blank, Duplicate, Acronym, Profanity, Undefined}
+
+// Removal is a word Lint removed from a list.
+type Removal struct {
+	Line   int // line number in the list, counting from 1
+	Word   string
+	Reason Reason
+}
+
+//go:embed acronyms.txt
+var acronymsFile string
+
+//go:embed profanity.txt
+var profanityFile string
+
+var (
+	acronyms  = wordSet(acronymsFile)
+	profanity = wordSet(profanityFile)
+)
+
+// interjections are real words without a vowel, which would otherwise pass for acronyms.
+var interjections = wordSet("brr\ncwm\ncwms\ncrwth\nhm\nhmm\nnth\npfft\npht\npsst\npst\nsh\nshh\ntsk\ntsks\nzzz\n")
+
+// Linter decides which words belong in a word list.
+type Linter struct {
+	Dictionary dictionary.Dictionary // optional; nil or empty keeps words without a definition, see Dictionary.Headword
+}
+
+// Lint checks a word list, one word per line, and returns the words to keep in their original order
+// along with everything it removed.
+func (l Linter) Lint(r io.Reader) (kept []string, removed []Removal, err error) {
+	seen := make(map[string]bool)
+	scanner := bufio.NewScanner(r)
+	for line := 1; scanner.Scan(); line++ {
+		word := strings.TrimSpace(scanner.Text())
+
+		var reason Reason
+		switch {
+		case word == "":
+			reason = Blank
+		case seen[word]:
+			reason = Duplicate
+		default:
+			reason, _ = l.Check(word)
+		}
+		seen[word] = true
+
+		if reason != "" {
+			removed = append(removed, Removal{Line: line, Word: word, Reason: reason})
+			continue
+		}
+		kept = append(kept, word)
+	}
+	return kept, removed, scanner.Err()
+}
+
+// Check reports whether word should be removed from a list, and why.
+// Unlike Lint it can't tell blanks or duplicates.
+func (l Linter) Check(word string) (Reason, bool) {
+	switch {
+	case IsAcronym(word):
+		return Acronym, true
+	case IsProfane(word):
+		return Profanity, true
+	case len(l.Dictionary) > 0 && l.Dictionary.Headword(word) == "":
+		return Undefined, true
+	}
+	return "", false
+}
+
+// IsAcronym reports whether word looks like an acronym or abbreviation rather than a word:
+// it has no vowel, a letter three times in a row, or it is a known acronym like "ceo", or the plural of one.
+func IsAcronym(word string) bool {
+	word = strings.ToLower(word)
+	if stem, ok := strings.CutSuffix(word, "s"); ok && stem != "" && isAcronym(stem) {
+		return true
+	}
+	return isAcronym(word)
+}
+
+func isAcronym(word string) bool {
+	if acronyms[word] {
+		return true
+	}
+	if !strings.ContainsAny(word, "aeiouy") && !interjections[word] {
+		return true
+	}
+	for i := 2; i < len(word); i++ {
+		if word[i] == word[i-1] && word[i] == word[i-2] {
+			return true
+		}
+	}
+	return false
+}
+
+// IsProfane reports whether word is a swear word or slur.
+func IsProfane(word string) bool {
+	return profanity[strings.ToLower(word)]
+}
+
+// wordSet reads one word per line, skipping blank lines and # comments.
+func wordSet(list string) map[string]bool {
+	set := make(map[string]bool)
+	for _, line := range strings.Split(list, "\n") {
+		line = strings.TrimSpace(line)
+		if line != "" && !strings.HasPrefix(line, "#") {
+			set[line] = true
+		}
+	}
+	return set
+}
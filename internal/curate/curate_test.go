@@ -0,0 +1,111 @@
+package curate_test
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/jharlan-hash/gospell/internal/api"
+	"github.com/jharlan-hash/gospell/internal/curate"
+	"github.com/jharlan-hash/gospell/internal/dictionary"
+)
+
+func TestIsAcronym(t *testing.T) {
+	tests := []struct {
+		word string
+		want bool
+	}{
+		{"aardvark", false},
+		{"abandon", false},
+		{"rhythm", false},
+		{"cwm", false},
+		{"hmm", false},
+		{"bbc", true},
+		{"html", true},
+		{"aaas", true},
+		{"www", true},
+		{"aarp", true},
+		{"ceo", true},
+		{"ceos", true},
+		{"CEO", true},
+		{"bus", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.word, func(t *testing.T) {
+			if got := curate.IsAcronym(tt.word); got != tt.want {
+				t.Errorf("IsAcronym(%q) = %v, want %v", tt.word, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestIsProfane(t *testing.T) {
+	tests := []struct {
+		word string
+		want bool
+	}{
+		{"shit", true},
+		{"Fucking", true},
+		{"cockatoo", false},
+		{"titmouse", false},
+		{"japan", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.word, func(t *testing.T) {
+			if got := curate.IsProfane(tt.word); got != tt.want {
+				t.Errorf("IsProfane(%q) = %v, want %v", tt.word, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestLinter_Lint(t *testing.T) {
+	tests := []struct {
+		name        string // description of this test case
+		dictionary  dictionary.Dictionary
+		input       string
+		wantKept    []string
+		wantRemoved []curate.Removal
+	}{
+		{"TestCleanList", nil, "abandon\nabbey\n", []string{"abandon", "abbey"}, nil},
+		{"TestBlankAndDuplicate", nil, "abandon\n\n  \nabandon\nabbey", []string{"abandon", "abbey"}, []curate.Removal{
+			{Line: 2, Word: "", Reason: curate.Blank},
+			{Line: 3, Word: "", Reason: curate.Blank},
+			{Line: 4, Word: "abandon", Reason: curate.Duplicate},
+		}},
+		{"TestAcronymAndProfanity", nil, "aarp\nabandon\nshit\n", []string{"abandon"}, []curate.Removal{
+			{Line: 1, Word: "aarp", Reason: curate.Acronym},
+			{Line: 3, Word: "shit", Reason: curate.Profanity},
+		}},
+		{"TestUndefined", dictionary.Dictionary{"abandon": {{Word: "abandon", Definition: "leave behind"}}}, "abandon\nabatis\n", []string{"abandon"}, []curate.Removal{
+			{Line: 2, Word: "abatis", Reason: curate.Undefined},
+		}},
+		{"TestInflectionOfDefinedLemma", dictionary.Dictionary{"abandon": {{Word: "abandon", PartOfSpeech: "verb", Definition: "leave behind"}}}, "abandoned\nabandoning\n", []string{"abandoned", "abandoning"}, nil},
+		{"TestEmptyDictionaryKeepsUndefined", dictionary.Dictionary{}, "abatis\n", []string{"abatis"}, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			kept, removed, err := curate.Linter{Dictionary: tt.dictionary}.Lint(strings.NewReader(tt.input))
+			if err != nil {
+				t.Fatalf("Lint() error = %v", err)
+			}
+			if !reflect.DeepEqual(kept, tt.wantKept) {
+				t.Errorf("Lint() kept = %q, want %q", kept, tt.wantKept)
+			}
+			if !reflect.DeepEqual(removed, tt.wantRemoved) {
+				t.Errorf("Lint() removed = %+v, want %+v", removed, tt.wantRemoved)
+			}
+		})
+	}
+}
+
+// TestEmbeddedListIsClean guards against the embedded word list drifting from "go generate ./internal/api".
+func TestEmbeddedListIsClean(t *testing.T) {
+	_, removed, err := curate.Linter{}.Lint(strings.NewReader(api.EmbeddedText()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, r := range removed {
+		t.Errorf("wordlist.txt:%d: %q: %s", r.Line, r.Word, r.Reason)
+	}
+}
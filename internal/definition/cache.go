@@ -3,22 +3,23 @@ package definition
 import (
 	"bytes"
 	_ "embed"
-	"encoding/gob"
 	"fmt"
+
+	"github.com/jharlan-hash/gospell/internal/dictionary"
 )
 
+// wordmap.gob is built from WordNet or Free Dictionary API data by cmd/gospell-dict.
+// A fresh checkout doesn't have it; go generate ./internal/definition downloads WordNet 3.0 and builds it.
+//
+//go:generate go run ../../cmd/gospell-dict -o wordmap.gob https://wordnetcode.princeton.edu/3.0/WNdb-3.0.tar.gz
 //go:embed wordmap.gob
 var fileBytes []byte
 
 // LoadCache loads the wordmap cache from a file.
 // It returns a Dictionary, which is a map of words to an Entry slice.
 func LoadCache() Dictionary {
-	read := bytes.NewReader(fileBytes)
-
-	enc := gob.NewDecoder(read)
-	cache := make(Dictionary)
-
-	if err := enc.Decode(&cache); err != nil {
+	cache, err := dictionary.Decode(bytes.NewReader(fileBytes))
+	if err != nil {
 		fmt.Println("Error decoding cache:", err)
 	}
 
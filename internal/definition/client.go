@@ -0,0 +1,64 @@
+package definition
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/jharlan-hash/gospell/internal/dictionary"
+)
+
+// DefaultBaseURL is the English endpoint of the Free Dictionary API.
+const DefaultBaseURL = "https://api.dictionaryapi.dev/api/v2/entries/en/"
+
+// maxResponse caps how much of a response is read; real ones are a few kilobytes.
+const maxResponse = 1 << 20
+
+// Client is a Provider that looks words up in an API following the dictionaryapi.dev JSON schema.
+type Client struct {
+	BaseURL string       // the word is appended to it, e.g. DefaultBaseURL
+	HTTP    *http.Client // optional; nil uses a client that gives up after 10 seconds
+}
+
+// defaultHTTP is used by clients without their own http.Client.
+var defaultHTTP = &http.Client{Timeout: 10 * time.Second}
+
+// Lookup asks the API for the definitions of word. The API answers 404 for words it doesn't know.
+func (c *Client) Lookup(ctx context.Context, word string) ([]Entry, error) {
+	endpoint := strings.TrimSuffix(c.BaseURL, "/") + "/" + url.PathEscape(word)
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, endpoint, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Accept", "application/json")
+
+	client := c.HTTP
+	if client == nil {
+		client = defaultHTTP
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("looking up %q: %w", word, err)
+	}
+	defer resp.Body.Close()
+
+	switch {
+	case resp.StatusCode == http.StatusNotFound:
+		return nil, ErrNotFound
+	case resp.StatusCode != http.StatusOK:
+		return nil, fmt.Errorf("looking up %q: %s", word, resp.Status)
+	}
+
+	d, err := dictionary.ReadFreeDictionary(io.LimitReader(resp.Body, maxResponse))
+	if err != nil {
+		return nil, fmt.Errorf("looking up %q: %w", word, err)
+	}
+	if entries := d[strings.ToLower(word)]; len(entries) > 0 {
+		return entries, nil
+	}
+	return nil, ErrNotFound
+}
@@ -1,24 +1,65 @@
 package definition
 
 import (
+	"context"
 	"fmt"
+	"regexp"
+
+	"github.com/jharlan-hash/gospell/internal/morphology"
 )
 
 type State struct {
 	Cache       Dictionary
+	Provider    Provider // optional; looks definitions up instead of Cache, e.g. a Chain
+	Masked      bool     // blank the word and its forms out of its definitions until Reveal, see Mask
 	Word        string
 	Index       int
 	Definitions []string
+
+	mask     *regexp.Regexp // matches the word and its forms
+	revealed bool           // Reveal was called for the word
+	examples [][]string     // example sentences of each definition
+}
+
+// Result is what looking up a word found. Unlike a State it is never changed,
+// so it can be made in the background and handed to Show.
+type Result struct {
+	Word    string
+	Entries []Entry
+	Err     error // ErrNotFound if the word has no definitions, or why the lookup failed
+}
+
+// LookUp looks word up in the Provider, or in the Cache if there is none. It only reads those two fields,
+// so it may run in the background while the State shows another word, as long as they aren't changed meanwhile.
+func (s *State) LookUp(ctx context.Context, word string) Result {
+	var p Provider = s.Cache
+	if s.Provider != nil {
+		p = s.Provider
+	}
+	entries, err := p.Lookup(ctx, word)
+	if err == nil && len(entries) == 0 {
+		err = ErrNotFound
+	}
+	return Result{Word: word, Entries: entries, Err: err}
 }
 
 // getDefinitionList returns a list of definitions for a given word from the cache.
 // It populates the definitions field in the State struct.
 // This function is called internally by GetDefinition to initialize the definitions list.
-func (s *State) GetDefinitionList() {
-	definitions := s.Cache[s.Word]
+// It returns ErrNotFound if the word has no definitions, or the error of a failed lookup.
+func (s *State) GetDefinitionList() error {
+	r := s.LookUp(context.Background(), s.Word)
+	s.setDefinitions(r.Entries)
+	return r.Err
+}
+
+// setDefinitions formats the definitions of the word for display, and keeps their examples.
+func (s *State) setDefinitions(definitions []Entry) {
 	list := make([]string, 0)
+	s.examples = s.examples[:0]
 
 	for _, definition := range definitions {
+		s.examples = append(s.examples, definition.Examples)
 		list = append(list,
 			fmt.Sprintf(
 				"(%d of %d) %s: %s",
@@ -35,27 +76,69 @@ func (s *State) GetDefinitionList() {
 
 // NextDefinition retrieves the next definition of a word from the cache.
 // If the user requests a definition past the last one, it returns the last definition.
+// It returns "" if the word has no definitions.
 func (s *State) NextDefinition() string {
-	if s.Index+1 >= len(s.Definitions) { // if user requests something past the end of the definition list
-		return s.Definitions[len(s.Definitions)-1] // return the last definition
-	} else { // increment index & change definition
-		s.Index++
-		return s.Definitions[s.Index]
+	if len(s.Definitions) == 0 {
+		return ""
+	}
+	if s.Index+1 < len(s.Definitions) { // unless the user requests something past the end of the definition list
+		s.Index++ // increment index & change definition
 	}
+	return s.current()
 }
 
 // PrevDefinition retrieves the previous definition of a word from the cache.
 // If the user requests a definition before the first one, it returns the first definition.
+// It returns "" if the word has no definitions.
 func (s *State) PrevDefinition() string {
-	if s.Index-1 < 0 { // if user requests something before the beginning of the definition list
-		return s.Definitions[0] // return the first definition
-	} else { // decrement index & change definition
-		s.Index--
+	if len(s.Definitions) == 0 {
+		return ""
+	}
+	if s.Index-1 >= 0 { // unless the user requests something before the beginning of the definition list
+		s.Index-- // decrement index & change definition
+	}
+	return s.current()
+}
+
+// Reveal returns the current definition unmasked and stops masking the definitions of the word,
+// e.g. once the user has answered. It returns "" if the word has no definitions.
+func (s *State) Reveal() string {
+	s.revealed = true
+	if len(s.Definitions) == 0 {
+		return ""
+	}
+	return s.current()
+}
+
+// Sentence returns a sentence using the word: an example of the current definition, or else of another one.
+// Sentences are sure to contain the word, so pass them through Mask before the word is spelled.
+// It returns "" if the word has no example sentences.
+func (s *State) Sentence() string {
+	if s.Index < len(s.examples) && len(s.examples[s.Index]) > 0 {
+		return s.examples[s.Index][0]
+	}
+	for _, examples := range s.examples {
+		if len(examples) > 0 {
+			return examples[0]
+		}
+	}
+	return ""
+}
+
+// current returns the definition at Index, masked if the word is still to be spelled.
+func (s *State) current() string {
+	if !s.Masked || s.revealed {
 		return s.Definitions[s.Index]
 	}
+	if s.mask == nil {
+		s.mask = morphology.Matcher(s.Word)
+	}
+	return s.mask.ReplaceAllLiteralString(s.Definitions[s.Index], Blank)
 }
 
 // GetDefinition retrieves the first definition of a word from the cache.
+// If the word has none it returns "" and ErrNotFound, or the error of a failed lookup,
+// and NextDefinition and PrevDefinition return "" until the next word.
 //
 // How To Use:
 //
@@ -68,19 +151,30 @@ func (s *State) PrevDefinition() string {
 // Example:
 //
 //	state := &definition.State{}
-//	firstDef := state.GetDefinition("example") // retrieves the first definition
+//	firstDef, err := state.GetDefinition("example") // retrieves the first definition
+//	if errors.Is(err, definition.ErrNotFound) {
+//		fmt.Println("no definition available")
+//	}
 //	fmt.Println(firstDef) // prints the first definition
 //	nextDef := state.NextDefinition() // retrieves the next definition
 //	fmt.Println(nextDef) // prints the next definition
 //	prevDef := state.PrevDefinition() // retrieves the previous definition
 //	fmt.Println(prevDef) // prints the previous definition
-func (m *State) GetDefinition(word string) string {
-	if m.Cache == nil { // Only load the cache if it's not already loaded.
+func (m *State) GetDefinition(word string) (string, error) {
+	if m.Cache == nil && m.Provider == nil { // Only load the cache if it's not already loaded.
 		m.Cache = LoadCache()
 	}
+	return m.Show(m.LookUp(context.Background(), word))
+}
 
-	m.Word = word
+// Show makes the word of r the current one and returns its first definition, like GetDefinition.
+func (m *State) Show(r Result) (string, error) {
+	m.Word = r.Word
 	m.Index = 0
-	m.GetDefinitionList() // populate the definitions list
-	return m.Definitions[m.Index] // return the first definition
+	m.mask, m.revealed = nil, false
+	m.setDefinitions(r.Entries) // populate the definitions list
+	if r.Err != nil {
+		return "", r.Err
+	}
+	return m.current(), nil // return the first definition
 }
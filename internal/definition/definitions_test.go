@@ -1,6 +1,7 @@
 package definition_test
 
 import (
+	"errors"
 	"testing"
 
 	"github.com/jharlan-hash/gospell/internal/definition"
@@ -10,19 +11,20 @@ func TestState_GetDefinition(t *testing.T) {
 	tests := []struct {
 		name string // description of this test case
 		// Named input parameters for target function.
-		word string
-		want string
+		word    string
+		want    string
+		wantErr error
 	}{
-		// TODO: Add test cases.
-		{"TestWithExample", "example", "(1 of 6) noun: an item of information that is typical of a class or group"},
+		{"TestWithExample", "example", "(1 of 6) noun: an item of information that is typical of a class or group", nil},
+		{"TestWithoutDefinitions", "xyzzy", "", definition.ErrNotFound},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			var m definition.State
-			got := m.GetDefinition(tt.word)
+			got, err := m.GetDefinition(tt.word)
 
-			if got != tt.want {
-				t.Errorf("GetDefinition() = %v, want %v", got, tt.want)
+			if got != tt.want || !errors.Is(err, tt.wantErr) {
+				t.Errorf("GetDefinition() = %v, %v, want %v, %v", got, err, tt.want, tt.wantErr)
 			}
 		})
 	}
@@ -38,14 +40,16 @@ func BenchmarkGetDefinition(b *testing.B) {
 func TestState_NextDefinition(t *testing.T) {
 	tests := []struct {
 		name string // description of this test case
+		word string
 		want string
 	}{
-		{"TestWithExample", "(2 of 6) noun: a representative form or pattern"},
+		{"TestWithExample", "example", "(2 of 6) noun: a representative form or pattern"},
+		{"TestWithoutDefinitions", "xyzzy", ""},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			var s = &definition.State{}
-			s.GetDefinition("example")
+			s.GetDefinition(tt.word)
 
 			got := s.NextDefinition()
 			if got != tt.want {
@@ -66,14 +70,16 @@ func BenchmarkNextDefinition(b *testing.B) {
 func TestState_PrevDefinition(t *testing.T) {
 	tests := []struct {
 		name string // description of this test case
+		word string
 		want string
 	}{
-		{"TestWithExample", "(1 of 6) noun: an item of information that is typical of a class or group"},
+		{"TestWithExample", "example", "(1 of 6) noun: an item of information that is typical of a class or group"},
+		{"TestWithoutDefinitions", "xyzzy", ""},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			var s = &definition.State{}
-			s.GetDefinition("example")
+			s.GetDefinition(tt.word)
 			s.NextDefinition()
 
 			got := s.PrevDefinition()
@@ -0,0 +1,13 @@
+package definition
+
+import "github.com/jharlan-hash/gospell/internal/morphology"
+
+// Blank is what Mask puts in place of the word.
+const Blank = "____"
+
+// Mask replaces word in text with Blank, and so its lemma and their other regular forms,
+// e.g. abandon and abandoning in a definition of abandonment, which would give the spelling away.
+// Only whole words are replaced, ignoring case.
+func Mask(text, word string) string {
+	return morphology.Matcher(word).ReplaceAllLiteralString(text, Blank)
+}
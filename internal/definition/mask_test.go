@@ -0,0 +1,76 @@
+package definition_test
+
+import (
+	"testing"
+
+	"github.com/jharlan-hash/gospell/internal/definition"
+)
+
+func TestMask(t *testing.T) {
+	tests := []struct {
+		text, word, want string
+	}{
+		{"the act of abandoning something", "abandonment", "the act of ____ something"},
+		{"Hope for the best; a hoped-for thing", "hoping", "____ for the best; a ____-for thing"},
+		{"carried out, as in to carry", "carries", "____ out, as in to ____"},
+		{"a small cat; a catalogue of cats", "cat", "a small ____; a catalogue of ____"},
+		{"nothing to hide here", "example", "nothing to hide here"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.word, func(t *testing.T) {
+			if got := definition.Mask(tt.text, tt.word); got != tt.want {
+				t.Errorf("Mask() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestState_Reveal(t *testing.T) {
+	d := definition.Dictionary{}
+	d.Add("abandonment", "noun", "the act of abandoning something")
+	d.Add("abandonment", "noun", "a feeling of being abandoned")
+	s := &definition.State{Provider: d, Masked: true}
+
+	if got, _ := s.GetDefinition("abandonment"); got != "(1 of 2) noun: the act of ____ something" {
+		t.Errorf("GetDefinition() = %q, want it masked", got)
+	}
+	if got := s.NextDefinition(); got != "(2 of 2) noun: a feeling of being ____" {
+		t.Errorf("NextDefinition() = %q, want it masked", got)
+	}
+	if got := s.Reveal(); got != "(2 of 2) noun: a feeling of being abandoned" {
+		t.Errorf("Reveal() = %q, want it unmasked", got)
+	}
+	if got := s.PrevDefinition(); got != "(1 of 2) noun: the act of abandoning something" {
+		t.Errorf("PrevDefinition() after Reveal() = %q, want it unmasked", got)
+	}
+	if got, _ := s.GetDefinition("abandonment"); got != "(1 of 2) noun: the act of ____ something" {
+		t.Errorf("GetDefinition() of the next word = %q, want it masked again", got)
+	}
+}
+
+func TestState_Sentence(t *testing.T) {
+	d := definition.Dictionary{}
+	d.Add("hop", "verb", "jump lightly", "hop on one foot")
+	d.Add("hop", "noun", "a short trip", "we hopped over to Paris", "a quick hop")
+	d.Add("hop", "noun", "the climbing plant of beer")
+	s := &definition.State{Provider: d}
+
+	tests := []struct {
+		name string
+		move func()
+		want string
+	}{
+		{"TestFromTheCurrentDefinition", func() { s.GetDefinition("hop") }, "hop on one foot"},
+		{"TestFromTheNextDefinition", func() { s.NextDefinition() }, "we hopped over to Paris"},
+		{"TestFromAnotherDefinition", func() { s.NextDefinition() }, "hop on one foot"},
+		{"TestWithoutExamples", func() { s.GetDefinition("xyzzy") }, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.move()
+			if got := s.Sentence(); got != tt.want {
+				t.Errorf("Sentence() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,32 @@
+package definition
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Missing is what a session does with a word that has no definitions.
+type Missing string
+
+const (
+	Show     Missing = "show"     // show that no definition is available
+	Skip     Missing = "skip"     // move on to another word
+	Fallback Missing = "fallback" // ask the online dictionary, see Client, for that word only
+	Sentence Missing = "sentence" // show the word list's example sentence instead, with the word blanked out
+)
+
+// MissingModes lists every Missing mode, the default first.
+var MissingModes = []Missing{Show, Skip, Fallback, Sentence}
+
+// ParseMissing returns the Missing mode named s, where the empty string is Show.
+func ParseMissing(s string) (Missing, error) {
+	if s == "" {
+		return Show, nil
+	}
+	for _, m := range MissingModes {
+		if Missing(strings.ToLower(s)) == m {
+			return m, nil
+		}
+	}
+	return "", fmt.Errorf("unknown missing definitions mode %q, want show, skip, fallback or sentence", s)
+}
@@ -0,0 +1,29 @@
+package definition_test
+
+import (
+	"testing"
+
+	"github.com/jharlan-hash/gospell/internal/definition"
+)
+
+func TestParseMissing(t *testing.T) {
+	tests := []struct {
+		s       string
+		want    definition.Missing
+		wantErr bool
+	}{
+		{"", definition.Show, false},
+		{"skip", definition.Skip, false},
+		{"fallback", definition.Fallback, false},
+		{"SENTENCE", definition.Sentence, false},
+		{"guess", "", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.s, func(t *testing.T) {
+			got, err := definition.ParseMissing(tt.s)
+			if (err != nil) != tt.wantErr || got != tt.want {
+				t.Errorf("ParseMissing() = %q, %v, want %q, error %v", got, err, tt.want, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,152 @@
+package definition
+
+import (
+	"cmp"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/jharlan-hash/gospell/internal/dictionary"
+)
+
+// ErrNotFound is returned by a Provider that has no definition of a word.
+var ErrNotFound = dictionary.ErrNotFound
+
+// Provider is a source of definitions, like the embedded dictionary or an online one.
+type Provider interface {
+	// Lookup returns the definitions of word, or ErrNotFound if it has none.
+	Lookup(ctx context.Context, word string) ([]Entry, error)
+}
+
+// Chain is a Provider that asks each of its providers in turn, in priority order,
+// and returns the definitions of the first that has some.
+type Chain []Provider
+
+// Lookup returns the first definitions found. If no provider has any, it returns the first error
+// other than ErrNotFound, e.g. a network error, or else ErrNotFound.
+func (c Chain) Lookup(ctx context.Context, word string) ([]Entry, error) {
+	var firstErr error
+	for _, p := range c {
+		entries, err := p.Lookup(ctx, word)
+		if err == nil && len(entries) > 0 {
+			return entries, nil
+		}
+		if err != nil && !errors.Is(err, ErrNotFound) && firstErr == nil {
+			firstErr = err
+		}
+	}
+	if firstErr != nil {
+		return nil, firstErr
+	}
+	return nil, ErrNotFound
+}
+
+// DefaultNotFoundTTL is how long Cached remembers that a word has no definition when NotFoundTTL is unset.
+const DefaultNotFoundTTL = 7 * 24 * time.Hour
+
+// Cached is a Provider that keeps the answers of a slow Provider, like an online dictionary, on disk.
+// Words the provider has no definition of are remembered too, for NotFoundTTL, so they aren't looked up
+// again right away but can get a definition later; other errors are not cached.
+type Cached struct {
+	Provider    Provider
+	Dir         string
+	NotFoundTTL time.Duration // 0 means DefaultNotFoundTTL
+}
+
+// DefaultCacheDir returns the directory where looked-up definitions are cached,
+// e.g. $XDG_CACHE_HOME/gospell/definitions on Linux.
+func DefaultCacheDir() (string, error) {
+	dir, err := os.UserCacheDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "gospell", "definitions"), nil
+}
+
+// Lookup returns the cached definitions of word, or looks them up and caches them.
+// A cache that can't be written only makes the next lookup slower.
+func (c *Cached) Lookup(ctx context.Context, word string) ([]Entry, error) {
+	path := c.path(word)
+	if b, err := os.ReadFile(path); err == nil {
+		var entries []Entry
+		if err := json.Unmarshal(b, &entries); err == nil {
+			if len(entries) > 0 {
+				return entries, nil
+			}
+			if !c.expired(path) {
+				return nil, ErrNotFound
+			}
+		}
+	}
+
+	entries, err := c.Provider.Lookup(ctx, word)
+	if err != nil && !errors.Is(err, ErrNotFound) {
+		return nil, err
+	}
+	if b, jsonErr := json.Marshal(entries); jsonErr == nil {
+		if os.MkdirAll(c.Dir, 0o755) == nil {
+			_ = os.WriteFile(path, b, 0o644)
+		}
+	}
+	return entries, err
+}
+
+// path returns where the definitions of word are cached. Words are hashed,
+// so that words differing only in case don't share a file on case-insensitive file systems.
+func (c *Cached) path(word string) string {
+	sum := sha256.Sum256([]byte(word))
+	return filepath.Join(c.Dir, hex.EncodeToString(sum[:])+".json")
+}
+
+// expired reports whether the cached answer at path that a word has no definition is older than NotFoundTTL.
+func (c *Cached) expired(path string) bool {
+	info, err := os.Stat(path)
+	if err != nil {
+		return true
+	}
+	return time.Since(info.ModTime()) > cmp.Or(c.NotFoundTTL, DefaultNotFoundTTL)
+}
+
+// DefaultOverlayPath returns where the user's own definitions are kept unless the config says otherwise,
+// e.g. $XDG_CONFIG_HOME/gospell/definitions.json on Linux.
+func DefaultOverlayPath() (string, error) {
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "gospell", "definitions.json"), nil
+}
+
+// LoadOverlay reads the user's own definitions from a JSON file mapping words to entries, e.g.
+//
+//	{"syzygy": [{"part_of_speech": "noun", "definition": "an alignment of three celestial bodies", "examples": ["a syzygy of the sun, moon and earth"]}]}
+//
+// Entries are numbered in order, so their word and numbers can be left out. A missing file is an empty overlay.
+func LoadOverlay(path string) (Dictionary, error) {
+	d := make(Dictionary)
+	b, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return d, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var overlay map[string][]Entry
+	if err := json.Unmarshal(b, &overlay); err != nil {
+		return nil, fmt.Errorf("parsing definitions file %s: %w", path, err)
+	}
+	for word, entries := range overlay {
+		for _, e := range entries {
+			d.Add(word, e.PartOfSpeech, e.Definition, e.Examples...)
+		}
+	}
+	return d, nil
+}
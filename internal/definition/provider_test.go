@@ -0,0 +1,261 @@
+package definition_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/jharlan-hash/gospell/internal/definition"
+)
+
+// standIn serves dictionaryapi.dev answers for "hello" and 404s for every other word, counting the requests.
+func standIn(t *testing.T, requests *atomic.Int32) *httptest.Server {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		switch path.Base(r.URL.Path) {
+		case "hello":
+			w.Write([]byte(`[{"word":"hello","meanings":[{"partOfSpeech":"exclamation","definitions":[{"definition":"used as a greeting"}]}]}]`))
+		case "teapot":
+			w.WriteHeader(http.StatusTeapot)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(`{"title":"No Definitions Found"}`))
+		}
+	}))
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+func TestClient_Lookup(t *testing.T) {
+	var requests atomic.Int32
+	c := &definition.Client{BaseURL: standIn(t, &requests).URL + "/api/v2/entries/en/"}
+
+	tests := []struct {
+		word    string
+		want    string // first definition
+		wantErr error  // nil, ErrNotFound, or any other error as errAny
+	}{
+		{"hello", "used as a greeting", nil},
+		{"xyzzy", "", definition.ErrNotFound},
+		{"teapot", "", errAny},
+	}
+	for _, tt := range tests {
+		t.Run(tt.word, func(t *testing.T) {
+			entries, err := c.Lookup(context.Background(), tt.word)
+			checkLookup(t, entries, err, tt.want, tt.wantErr)
+		})
+	}
+}
+
+func TestChain_Lookup(t *testing.T) {
+	overlay := definition.Dictionary{}
+	overlay.Add("cat", "noun", "my own definition")
+	embedded := definition.Dictionary{}
+	embedded.Add("cat", "noun", "a small feline")
+	embedded.Add("dog", "noun", "a domesticated canine")
+	var requests atomic.Int32
+	remote := &definition.Client{BaseURL: standIn(t, &requests).URL}
+	chain := definition.Chain{overlay, embedded, remote}
+
+	tests := []struct {
+		word    string
+		want    string
+		wantErr error
+	}{
+		{"cat", "my own definition", nil},
+		{"dog", "a domesticated canine", nil},
+		{"hello", "used as a greeting", nil},
+		{"xyzzy", "", definition.ErrNotFound},
+		{"teapot", "", errAny}, // a failed lookup isn't reported as missing
+	}
+	for _, tt := range tests {
+		t.Run(tt.word, func(t *testing.T) {
+			entries, err := chain.Lookup(context.Background(), tt.word)
+			checkLookup(t, entries, err, tt.want, tt.wantErr)
+		})
+	}
+	if n := requests.Load(); n != 3 {
+		t.Errorf("made %d requests, want 3: only for words the dictionaries lack", n)
+	}
+}
+
+func TestCached_Lookup(t *testing.T) {
+	var requests atomic.Int32
+	cached := &definition.Cached{
+		Provider: &definition.Client{BaseURL: standIn(t, &requests).URL},
+		Dir:      t.TempDir(),
+	}
+
+	for range 2 {
+		entries, err := cached.Lookup(context.Background(), "hello")
+		checkLookup(t, entries, err, "used as a greeting", nil)
+		_, err = cached.Lookup(context.Background(), "xyzzy")
+		checkLookup(t, nil, err, "", definition.ErrNotFound)
+		_, err = cached.Lookup(context.Background(), "teapot")
+		checkLookup(t, nil, err, "", errAny)
+	}
+	// hello and xyzzy are cached after the first round, the failing teapot is not
+	if n := requests.Load(); n != 4 {
+		t.Errorf("made %d requests, want 4", n)
+	}
+}
+
+func TestCached_NotFoundTTL(t *testing.T) {
+	var requests atomic.Int32
+	dir := t.TempDir()
+	cached := &definition.Cached{
+		Provider:    &definition.Client{BaseURL: standIn(t, &requests).URL},
+		Dir:         dir,
+		NotFoundTTL: time.Hour,
+	}
+
+	for range 2 {
+		_, err := cached.Lookup(context.Background(), "xyzzy")
+		checkLookup(t, nil, err, "", definition.ErrNotFound)
+	}
+	if n := requests.Load(); n != 1 {
+		t.Fatalf("made %d requests, want 1 while the miss is fresh", n)
+	}
+
+	files, err := os.ReadDir(dir)
+	if err != nil || len(files) != 1 {
+		t.Fatalf("cache holds %v, %v, want one file", files, err)
+	}
+	stale := time.Now().Add(-2 * time.Hour)
+	if err := os.Chtimes(filepath.Join(dir, files[0].Name()), stale, stale); err != nil {
+		t.Fatal(err)
+	}
+	_, err = cached.Lookup(context.Background(), "xyzzy")
+	checkLookup(t, nil, err, "", definition.ErrNotFound)
+	if n := requests.Load(); n != 2 {
+		t.Errorf("made %d requests, want 2: an expired miss is looked up again", n)
+	}
+}
+
+func TestCached_Case(t *testing.T) {
+	var requests atomic.Int32
+	dir := t.TempDir()
+	cached := &definition.Cached{
+		Provider: &definition.Client{BaseURL: standIn(t, &requests).URL},
+		Dir:      dir,
+	}
+
+	// the stand-in only knows the lower-case word, so Hello is cached as a miss
+	_, err := cached.Lookup(context.Background(), "Hello")
+	checkLookup(t, nil, err, "", definition.ErrNotFound)
+	entries, err := cached.Lookup(context.Background(), "hello")
+	checkLookup(t, entries, err, "used as a greeting", nil)
+
+	files, err := os.ReadDir(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, f := range files {
+		if strings.Contains(strings.ToLower(f.Name()), "hello") {
+			t.Errorf("cache file %q is named after the word, want a hash", f.Name())
+		}
+	}
+	if len(files) != 2 {
+		t.Errorf("cache holds %d files, want one per spelling", len(files))
+	}
+}
+
+func TestLoadOverlay(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "definitions.json")
+	if d, err := definition.LoadOverlay(path); err != nil || len(d) != 0 {
+		t.Errorf("LoadOverlay() of a missing file = %v, %v, want an empty overlay", d, err)
+	}
+
+	content := `{"Syzygy": [{"part_of_speech": "noun", "definition": "an alignment of three celestial bodies"}, {"definition": "a pair of connected things"}]}`
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	d, err := definition.LoadOverlay(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	entries := d["syzygy"]
+	if len(entries) != 2 || entries[1].DefinitionIndex != 2 || entries[1].NumDefinitions != 2 || entries[0].PartOfSpeech != "noun" {
+		t.Errorf("LoadOverlay() = %+v, want two numbered definitions of syzygy", d)
+	}
+
+	if err := os.WriteFile(path, []byte("not json"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := definition.LoadOverlay(path); err == nil {
+		t.Error("LoadOverlay() of a broken file succeeded, want an error")
+	}
+}
+
+func TestState_Provider(t *testing.T) {
+	d := definition.Dictionary{}
+	d.Add("cat", "noun", "a small feline")
+	var requests atomic.Int32
+	s := &definition.State{Provider: definition.Chain{d, &definition.Client{BaseURL: standIn(t, &requests).URL}}}
+
+	tests := []struct {
+		word    string
+		want    string
+		wantErr error
+	}{
+		{"cat", "(1 of 1) noun: a small feline", nil},
+		{"xyzzy", "", definition.ErrNotFound},
+		{"teapot", "", errAny},
+	}
+	for _, tt := range tests {
+		t.Run(tt.word, func(t *testing.T) {
+			got, err := s.GetDefinition(tt.word)
+			if got != tt.want {
+				t.Errorf("GetDefinition() = %q, want %q", got, tt.want)
+			}
+			checkLookup(t, nil, err, "", tt.wantErr)
+			if next := s.NextDefinition(); next != tt.want {
+				t.Errorf("NextDefinition() = %q, want %q", next, tt.want)
+			}
+		})
+	}
+}
+
+// errAny stands for any error other than ErrNotFound.
+var errAny = errors.New("any error")
+
+func checkLookup(t *testing.T, entries []definition.Entry, err error, want string, wantErr error) {
+	t.Helper()
+	switch {
+	case wantErr == errAny:
+		if err == nil || errors.Is(err, definition.ErrNotFound) {
+			t.Errorf("Lookup() error = %v, want a failed lookup", err)
+		}
+	case !errors.Is(err, wantErr):
+		t.Errorf("Lookup() error = %v, want %v", err, wantErr)
+	case want != "" && (len(entries) == 0 || entries[0].Definition != want):
+		t.Errorf("Lookup() = %+v, want %q first", entries, want)
+	}
+}
+
+func TestState_LookUp(t *testing.T) {
+	d := definition.Dictionary{}
+	d.Add("cat", "noun", "a small feline")
+	d.Add("dog", "noun", "a domesticated canine")
+	s := &definition.State{Provider: d}
+	s.GetDefinition("cat")
+
+	r := s.LookUp(context.Background(), "dog")
+	if s.Word != "cat" || s.NextDefinition() != "(1 of 1) noun: a small feline" {
+		t.Errorf("LookUp() changed the state to %q, want it left for Show", s.Word)
+	}
+	if got, err := s.Show(r); got != "(1 of 1) noun: a domesticated canine" || err != nil || s.Word != "dog" {
+		t.Errorf("Show() = %q, %v, want the definition of dog", got, err)
+	}
+	if _, err := s.Show(s.LookUp(context.Background(), "xyzzy")); !errors.Is(err, definition.ErrNotFound) {
+		t.Errorf("Show() error = %v, want %v", err, definition.ErrNotFound)
+	}
+}
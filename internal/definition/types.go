@@ -1,14 +1,9 @@
 package definition
 
-// Dictionary represents the entire JSON structure
-// The JSON is a map where keys are words and values are arrays of entries
-type Dictionary map[string][]Entry
+import "github.com/jharlan-hash/gospell/internal/dictionary"
 
-// Entry represents a single word definition
-type Entry struct {
-	Word            string `json:"word"`
-	DefinitionIndex int64  `json:"definition_index"`
-	NumDefinitions  int64  `json:"num_definitions"`
-	PartOfSpeech    string `json:"part_of_speech"`
-	Definition      string `json:"definition"`
-}
+// Dictionary and Entry live in package dictionary, so that it can be built without the embedded one.
+type (
+	Dictionary = dictionary.Dictionary
+	Entry      = dictionary.Entry
+)
@@ -0,0 +1,67 @@
+package dictionary
+
+import (
+	"encoding/gob"
+	"io"
+	"slices"
+	"strings"
+)
+
+// Decode reads a Dictionary in the gob format of wordmap.gob.
+// On error it returns whatever it could decode, which may be empty.
+func Decode(r io.Reader) (Dictionary, error) {
+	d := make(Dictionary)
+	err := gob.NewDecoder(r).Decode(&d)
+	return d, err
+}
+
+// Encode writes d in the gob format of wordmap.gob.
+func (d Dictionary) Encode(w io.Writer) error {
+	return gob.NewEncoder(w).Encode(d)
+}
+
+// Add appends a definition of word, lower-cased, with sentences using the word in that sense,
+// and renumbers the word's definitions. Empty definitions are ignored, and a duplicate one only adds its new examples.
+func (d Dictionary) Add(word, partOfSpeech, definition string, examples ...string) {
+	word = strings.ToLower(strings.TrimSpace(word))
+	definition = strings.TrimSpace(definition)
+	if word == "" || definition == "" {
+		return
+	}
+	for i, e := range d[word] {
+		if e.PartOfSpeech == partOfSpeech && e.Definition == definition {
+			d[word][i].Examples = addExamples(e.Examples, examples)
+			return
+		}
+	}
+
+	entries := append(d[word], Entry{Word: word, PartOfSpeech: partOfSpeech, Definition: definition, Examples: addExamples(nil, examples)})
+	for i := range entries {
+		entries[i].DefinitionIndex = int64(i + 1)
+		entries[i].NumDefinitions = int64(len(entries))
+	}
+	d[word] = entries
+}
+
+// addExamples appends the non-empty examples that aren't in list yet.
+func addExamples(list, examples []string) []string {
+	for _, ex := range examples {
+		if ex = strings.TrimSpace(ex); ex != "" && !slices.Contains(list, ex) {
+			list = append(list, ex)
+		}
+	}
+	return list
+}
+
+// Merge adds the words of other that d doesn't define yet, so that d's own definitions take precedence.
+// It returns the number of words added.
+func (d Dictionary) Merge(other Dictionary) int {
+	added := 0
+	for word, entries := range other {
+		if len(d[word]) == 0 && len(entries) > 0 {
+			d[word] = entries
+			added++
+		}
+	}
+	return added
+}
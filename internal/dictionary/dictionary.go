@@ -0,0 +1,77 @@
+// Package dictionary holds the word definitions gospell shows, and builds them from WordNet
+// and Free Dictionary API data. Unlike package definition it embeds no dictionary,
+// so cmd/gospell-dict can build wordmap.gob in a checkout that doesn't have one yet.
+package dictionary
+
+import (
+	"context"
+	"errors"
+	"slices"
+
+	"github.com/jharlan-hash/gospell/internal/morphology"
+)
+
+// ErrNotFound is returned by a lookup that found no definition of a word.
+var ErrNotFound = errors.New("no definition found")
+
+// Dictionary represents the entire JSON structure
+// The JSON is a map where keys are words and values are arrays of entries
+type Dictionary map[string][]Entry
+
+// Entry represents a single word definition
+type Entry struct {
+	Word            string   `json:"word"`
+	DefinitionIndex int64    `json:"definition_index"`
+	NumDefinitions  int64    `json:"num_definitions"`
+	PartOfSpeech    string   `json:"part_of_speech"`
+	Definition      string   `json:"definition"`
+	Examples        []string `json:"examples,omitempty"` // sentences using the word in this sense
+}
+
+// PartsOfSpeech returns the distinct parts of speech of word, e.g. "noun" and "verb", in dictionary order.
+func (d Dictionary) PartsOfSpeech(word string) []string {
+	var parts []string
+	for _, entry := range d[word] {
+		if entry.PartOfSpeech != "" && !slices.Contains(parts, entry.PartOfSpeech) {
+			parts = append(parts, entry.PartOfSpeech)
+		}
+	}
+	return parts
+}
+
+// Lookup returns the definitions of word in d, or ErrNotFound, so a Dictionary is a definition.Provider.
+// A word d doesn't list gets the definitions of its Headword, e.g. hopped those of hop.
+func (d Dictionary) Lookup(ctx context.Context, word string) ([]Entry, error) {
+	if entries := d[d.Headword(word)]; len(entries) > 0 {
+		return entries, nil
+	}
+	return nil, ErrNotFound
+}
+
+// inflected are the parts of speech whose lemmas take each kind of form.
+// Nouns in -ment mean something else than their verb (argue, argument), so they need their own definitions.
+var inflected = map[morphology.Kind][]string{
+	morphology.S:           {"noun", "verb"},
+	morphology.Past:        {"verb"},
+	morphology.Participle:  {"verb"},
+	morphology.Comparative: {"adjective"},
+	morphology.Superlative: {"adjective"},
+}
+
+// Headword returns the word whose definitions define word: word itself if d lists it, or else the lemma
+// of a regular form, like hop for hopped or cat for cats, as a part of speech that has that form.
+// WordNet lists lemmas only, so this is how a dictionary built from it defines plurals and inflections.
+// It returns "" if d defines neither.
+func (d Dictionary) Headword(word string) string {
+	if len(d[word]) > 0 {
+		return word
+	}
+	for _, f := range morphology.Analyze(word, func(lemma string) bool { return len(d[lemma]) > 0 }) {
+		for _, pos := range d.PartsOfSpeech(f.Lemma) {
+			if slices.Contains(inflected[f.Kind], pos) {
+				return f.Lemma
+			}
+		}
+	}
+	return ""
+}
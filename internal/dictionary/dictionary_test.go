@@ -0,0 +1,152 @@
+package dictionary_test
+
+import (
+	"bytes"
+	"context"
+	"reflect"
+	"strings"
+	"testing"
+	"testing/fstest"
+
+	"github.com/jharlan-hash/gospell/internal/dictionary"
+)
+
+// wordNet is a tiny WordNet database in the format of WordNet 3.0.
+var wordNet = fstest.MapFS{
+	"index.noun": {Data: []byte("  1 This software and database is being provided to you, the LICENSEE, by\n" +
+		"cat n 2 1 @ 2 1 02121620 09900153\n" +
+		"ice_cream n 1 1 @ 1 0 07614500\n")},
+	"data.noun": {Data: []byte("  1 This software and database is being provided to you, the LICENSEE, by\n" +
+		"02121620 05 n 03 cat 0 true_cat 0 | feline mammal usually having thick soft fur; \"cats purr\"; \"felines purr too\"\n" +
+		"07614500 13 n 01 ice_cream 0 | frozen dessert\n" +
+		"09900153 18 n 01 cat 1 | a spiteful woman gossip; \"what a cat she is!\" - Shakespeare\n")},
+	"index.verb": {Data: []byte("cat v 1 1 @ 1 0 01411085\n")},
+	"data.verb":  {Data: []byte("01411085 35 v 01 cat 1 | beat with a cat-o'-nine-tails\n")},
+	"index.adj":  {Data: []byte("")},
+	"data.adj":   {Data: []byte("")},
+	"index.adv":  {Data: []byte("")},
+	"data.adv":   {Data: []byte("")},
+}
+
+func TestReadWordNet(t *testing.T) {
+	if !dictionary.IsWordNet(wordNet) {
+		t.Fatal("IsWordNet() = false, want true")
+	}
+	d, err := dictionary.ReadWordNet(wordNet)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := dictionary.Dictionary{"cat": {
+		{Word: "cat", DefinitionIndex: 1, NumDefinitions: 3, PartOfSpeech: "noun", Definition: "feline mammal usually having thick soft fur", Examples: []string{"cats purr"}},
+		{Word: "cat", DefinitionIndex: 2, NumDefinitions: 3, PartOfSpeech: "noun", Definition: "a spiteful woman gossip", Examples: []string{"what a cat she is!"}},
+		{Word: "cat", DefinitionIndex: 3, NumDefinitions: 3, PartOfSpeech: "verb", Definition: "beat with a cat-o'-nine-tails"},
+	}}
+	if !reflect.DeepEqual(d, want) {
+		t.Errorf("ReadWordNet() = %+v, want %+v", d, want)
+	}
+}
+
+func TestReadFreeDictionary(t *testing.T) {
+	tests := []struct {
+		name  string // description of this test case
+		input string
+		want  []string // definitions of "hello"
+	}{
+		{"TestResponse", `[{"word":"hello","meanings":[{"partOfSpeech":"noun","definitions":[{"definition":"a greeting","example":"hello, everyone"}]}]}]`, []string{"a greeting"}},
+		{"TestEntry", `{"word":"Hello","meanings":[{"partOfSpeech":"noun","definitions":[{"definition":"a greeting"}]}]}`, []string{"a greeting"}},
+		{"TestStream", `[{"word":"hello","meanings":[{"partOfSpeech":"noun","definitions":[{"definition":"a greeting"}]}]}]
+[{"word":"hello","meanings":[{"partOfSpeech":"verb","definitions":[{"definition":"to say hello"}]}]}]`, []string{"a greeting", "to say hello"}},
+		{"TestByWord", `{"hello":[{"word":"hello","meanings":[{"partOfSpeech":"noun","definitions":[{"definition":"a greeting"}]}]}]}`, []string{"a greeting"}},
+		{"TestNotFound", `{"title":"No Definitions Found","message":"Sorry pal"}`, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d, err := dictionary.ReadFreeDictionary(strings.NewReader(tt.input))
+			if err != nil {
+				t.Fatal(err)
+			}
+			var got []string
+			for _, e := range d["hello"] {
+				got = append(got, e.Definition)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("definitions = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDictionary_EncodeDecode(t *testing.T) {
+	d := make(dictionary.Dictionary)
+	d.Add("cat", "noun", "a small feline")
+	d.Add("cat", "noun", "a small feline", "the cat purred") // duplicates are dropped, but add their examples
+	d.Add("dog", "noun", "a domesticated canine")
+
+	var buf bytes.Buffer
+	if err := d.Encode(&buf); err != nil {
+		t.Fatal(err)
+	}
+	got, err := dictionary.Decode(&buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(got, d) || len(got["cat"]) != 1 || len(got["cat"][0].Examples) != 1 {
+		t.Errorf("Decode() = %+v, want %+v", got, d)
+	}
+}
+
+func TestDictionary_Merge(t *testing.T) {
+	d := dictionary.Dictionary{}
+	d.Add("cat", "noun", "from the first source")
+	other := dictionary.Dictionary{}
+	other.Add("cat", "noun", "from the second source")
+	other.Add("dog", "noun", "only in the second source")
+
+	if added := d.Merge(other); added != 1 {
+		t.Errorf("Merge() = %d, want 1", added)
+	}
+	if got := d["cat"][0].Definition; got != "from the first source" {
+		t.Errorf("cat = %q, want the first source's definition", got)
+	}
+	if len(d["dog"]) != 1 {
+		t.Errorf("dog = %+v, want the second source's definition", d["dog"])
+	}
+}
+
+func TestDictionary_Headword(t *testing.T) {
+	// lemmas only, as WordNet lists them
+	d := dictionary.Dictionary{}
+	d.Add("hop", "verb", "jump lightly")
+	d.Add("cat", "noun", "a small feline")
+	d.Add("happy", "adjective", "enjoying well-being and contentment")
+	d.Add("argue", "verb", "have an argument about something")
+
+	tests := []struct {
+		word string
+		want string
+	}{
+		{"hop", "hop"},
+		{"hopped", "hop"},
+		{"hopping", "hop"},
+		{"hops", "hop"},
+		{"cats", "cat"},
+		{"happier", "happy"},
+		{"happiest", "happy"},
+		{"catted", ""},   // cat is no verb
+		{"hopper", ""},   // nor hop an adjective
+		{"argument", ""}, // a -ment noun means something else than its verb
+		{"dog", ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.word, func(t *testing.T) {
+			if got := d.Headword(tt.word); got != tt.want {
+				t.Errorf("Headword(%q) = %q, want %q", tt.word, got, tt.want)
+			}
+			entries, err := d.Lookup(context.Background(), tt.word)
+			if found := err == nil && len(entries) > 0; found != (tt.want != "") {
+				t.Errorf("Lookup(%q) = %+v, %v, want the definitions of %q", tt.word, entries, err, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,89 @@
+package dictionary
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+)
+
+// freeEntry is an entry of the Free Dictionary API (https://dictionaryapi.dev),
+// which answers a lookup with an array of them.
+type freeEntry struct {
+	Word     string `json:"word"`
+	Meanings []struct {
+		PartOfSpeech string `json:"partOfSpeech"`
+		Definitions  []struct {
+			Definition string `json:"definition"`
+			Example    string `json:"example"`
+		} `json:"definitions"`
+	} `json:"meanings"`
+}
+
+// ReadFreeDictionary reads Free Dictionary API responses into a Dictionary.
+// The input is a stream of JSON values, each an API response (an array of entries), a single entry,
+// or an object mapping words to responses, so both saved responses and whole dumps can be read.
+func ReadFreeDictionary(r io.Reader) (Dictionary, error) {
+	d := make(Dictionary)
+	dec := json.NewDecoder(r)
+	for {
+		var raw json.RawMessage
+		if err := dec.Decode(&raw); errors.Is(err, io.EOF) {
+			return d, nil
+		} else if err != nil {
+			return d, err
+		}
+
+		entries, err := decodeFreeEntries(raw)
+		if err != nil {
+			return d, err
+		}
+		for _, e := range entries {
+			for _, m := range e.Meanings {
+				for _, def := range m.Definitions {
+					d.Add(e.Word, m.PartOfSpeech, def.Definition, def.Example)
+				}
+			}
+		}
+	}
+}
+
+// decodeFreeEntries decodes one JSON value of a Free Dictionary dump, see ReadFreeDictionary.
+func decodeFreeEntries(raw json.RawMessage) ([]freeEntry, error) {
+	raw = bytes.TrimSpace(raw)
+	if len(raw) == 0 {
+		return nil, nil
+	}
+
+	switch raw[0] {
+	case '[':
+		var entries []freeEntry
+		err := json.Unmarshal(raw, &entries)
+		return entries, err
+	case '{':
+		var probe map[string]json.RawMessage
+		if err := json.Unmarshal(raw, &probe); err != nil {
+			return nil, err
+		}
+		if _, ok := probe["meanings"]; ok {
+			var e freeEntry
+			err := json.Unmarshal(raw, &e)
+			return []freeEntry{e}, err
+		}
+		if _, ok := probe["title"]; ok {
+			return nil, nil // the API's "No Definitions Found" answer
+		}
+
+		var entries []freeEntry
+		for word, value := range probe {
+			var response []freeEntry
+			if err := json.Unmarshal(value, &response); err != nil {
+				return nil, fmt.Errorf("entries of %q: %w", word, err)
+			}
+			entries = append(entries, response...)
+		}
+		return entries, nil
+	}
+	return nil, fmt.Errorf("want an array or object of dictionary entries, got %.20s", raw)
+}
@@ -0,0 +1,149 @@
+package dictionary
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"io/fs"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/jharlan-hash/gospell/internal/morphology"
+)
+
+// wordNetFiles are the suffixes of WordNet's index.* and data.* files and the part of speech each holds,
+// in the order their definitions are listed.
+var wordNetFiles = []struct{ suffix, partOfSpeech string }{
+	{"noun", "noun"},
+	{"verb", "verb"},
+	{"adj", "adjective"},
+	{"adv", "adverb"},
+}
+
+// IsWordNet reports whether fsys looks like a WordNet database directory (the dict directory of a WordNet release).
+func IsWordNet(fsys fs.FS) bool {
+	_, err := fs.Stat(fsys, "index.noun")
+	return err == nil
+}
+
+// ReadWordNet reads the WordNet database in fsys into a Dictionary.
+// A word's definitions are its nouns, verbs, adjectives and adverbs, each in WordNet's sense order,
+// which puts the most common sense first, with the gloss's examples that use the word or one of its forms.
+// Collocations like "ice_cream" are left out.
+func ReadWordNet(fsys fs.FS) (Dictionary, error) {
+	d := make(Dictionary)
+	for _, file := range wordNetFiles {
+		glosses, err := readWordNetData(fsys, "data."+file.suffix)
+		if err != nil {
+			return nil, err
+		}
+		if err := readWordNetIndex(fsys, "index."+file.suffix, func(lemma string, offsets []string) {
+			uses := morphology.Matcher(lemma)
+			for _, offset := range offsets {
+				g := glosses[offset]
+				// a synset's examples are shared by its synonyms, so keep those using this one
+				var examples []string
+				for _, ex := range g.examples {
+					if uses.MatchString(ex) {
+						examples = append(examples, ex)
+					}
+				}
+				d.Add(lemma, file.partOfSpeech, g.definition, examples...)
+			}
+		}); err != nil {
+			return nil, err
+		}
+	}
+	return d, nil
+}
+
+// wordNetGloss is the gloss of a synset, split into its definition and example sentences.
+type wordNetGloss struct {
+	definition string
+	examples   []string
+}
+
+// readWordNetData returns the glosses of the synsets in a data.* file, by synset offset.
+func readWordNetData(fsys fs.FS, name string) (map[string]wordNetGloss, error) {
+	glosses := make(map[string]wordNetGloss)
+	err := readWordNetLines(fsys, name, func(line string) error {
+		offset, _, _ := strings.Cut(line, " ")
+		_, gloss, ok := strings.Cut(line, " | ")
+		if !ok {
+			return fmt.Errorf("synset %s has no gloss", offset)
+		}
+		glosses[offset] = wordNetGloss{definitionOf(gloss), examplesOf(gloss)}
+		return nil
+	})
+	return glosses, err
+}
+
+// readWordNetIndex calls add with each lemma of an index.* file and the offsets of its synsets, in sense order.
+func readWordNetIndex(fsys fs.FS, name string, add func(lemma string, offsets []string)) error {
+	return readWordNetLines(fsys, name, func(line string) error {
+		fields := strings.Fields(line)
+		if len(fields) < 4 {
+			return fmt.Errorf("short index line %q", line)
+		}
+		count, err := strconv.Atoi(fields[2])
+		if err != nil || count > len(fields)-4 {
+			return fmt.Errorf("bad synset count in index line %q", line)
+		}
+		if lemma := fields[0]; !strings.Contains(lemma, "_") {
+			add(lemma, fields[len(fields)-count:])
+		}
+		return nil
+	})
+}
+
+// readWordNetLines calls f with each line of a WordNet database file, skipping the license header.
+func readWordNetLines(fsys fs.FS, name string, f func(line string) error) error {
+	file, err := fsys.Open(name)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	r := bufio.NewReader(file)
+	for n := 1; ; n++ {
+		line, err := r.ReadString('\n')
+		if line = strings.TrimRight(line, "\r\n"); line != "" && !strings.HasPrefix(line, "  ") {
+			if err := f(line); err != nil {
+				return fmt.Errorf("%s:%d: %w", name, n, err)
+			}
+		}
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+	}
+}
+
+// definitionOf returns the definition of a WordNet gloss, without the examples that follow it:
+// `a small domesticated carnivore; "the cat sat on the mat"` becomes "a small domesticated carnivore".
+func definitionOf(gloss string) string {
+	if i := strings.Index(gloss, `; "`); i >= 0 {
+		gloss = gloss[:i]
+	}
+	return strings.TrimSpace(gloss)
+}
+
+// wordNetExample matches an example sentence of a WordNet gloss, which is quoted.
+var wordNetExample = regexp.MustCompile(`"([^"]+)"`)
+
+// examplesOf returns the examples that follow the definition of a WordNet gloss:
+// `a small domesticated carnivore; "the cat sat on the mat"` gives "the cat sat on the mat".
+func examplesOf(gloss string) []string {
+	i := strings.Index(gloss, `; "`)
+	if i < 0 {
+		return nil
+	}
+	var examples []string
+	for _, m := range wordNetExample.FindAllStringSubmatch(gloss[i:], -1) {
+		examples = append(examples, strings.TrimSpace(m[1]))
+	}
+	return examples
+}
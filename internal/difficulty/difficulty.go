@@ -0,0 +1,199 @@
+// Package difficulty scores how hard a word is to spell.
+//
+// A score runs from 0 (trivial) to 100 (bee-final material) and combines
+// the word's length, its syllables, rare letter patterns, silent letters and,
+// when a Ranker is available, how rarely it occurs in a corpus.
+// Levels split the scores of the embedded word list, ranked by its embedded frequency table,
+// into five bands of roughly equal size.
+package difficulty
+
+import (
+	"math"
+	"strings"
+)
+
+// MaxScore is the highest score a word can get.
+const MaxScore = 100
+
+// Ranker reports how common a word is. Rank 1 is the most frequent word of a corpus;
+// ok is false for words the corpus doesn't contain.
+type Ranker interface {
+	Rank(word string) (rank int, ok bool)
+}
+
+// Scorer scores words, optionally taking their corpus frequency into account.
+type Scorer struct {
+	Ranker Ranker // optional; nil counts every word as of middling frequency
+}
+
+// Range is a band of scores, both ends inclusive.
+type Range struct {
+	Min, Max float64
+}
+
+// All is the Range of every score.
+var All = Range{0, MaxScore}
+
+// Contains reports whether score lies within r.
+func (r Range) Contains(score float64) bool {
+	return score >= r.Min && score <= r.Max
+}
+
+// Levels are the score ranges of the session levels, from 1 for beginners to 5 for bee champions.
+// Scores are rounded to one decimal, so the ranges don't leave gaps.
+var Levels = []Range{
+	{0, 31.9},
+	{32, 39.9},
+	{40, 45.9},
+	{46, 53.9},
+	{54, MaxScore},
+}
+
+// Level returns the score range of level n, counting from 1.
+func Level(n int) (Range, bool) {
+	if n < 1 || n > len(Levels) {
+		return Range{}, false
+	}
+	return Levels[n-1], true
+}
+
+// weights of each feature in the score; they add up to 1.
+const (
+	lengthWeight    = 0.25
+	syllableWeight  = 0.20
+	patternWeight   = 0.20
+	silentWeight    = 0.15
+	frequencyWeight = 0.20
+)
+
+// rareRank is the rank from which on a word counts as fully rare.
+const rareRank = 100_000
+
+// Score returns the difficulty of word, from 0 to MaxScore.
+func (s Scorer) Score(word string) float64 {
+	w := letters(word)
+	if w == "" {
+		return 0
+	}
+
+	score := lengthWeight*clamp(float64(len(w)-3)/11) +
+		syllableWeight*clamp(float64(Syllables(w)-1)/4) +
+		patternWeight*clamp(float64(rarePatterns(w))/3) +
+		silentWeight*clamp(float64(SilentLetters(w))/2) +
+		frequencyWeight*s.rarity(w)
+	return math.Round(score*MaxScore*10) / 10
+}
+
+// Score returns the difficulty of word without frequency information.
+func Score(word string) float64 {
+	return Scorer{}.Score(word)
+}
+
+// rarity is 0 for the most common words and 1 for words past rareRank or missing from the corpus.
+func (s Scorer) rarity(word string) float64 {
+	if s.Ranker == nil {
+		return 0.5
+	}
+	rank, ok := s.Ranker.Rank(word)
+	if !ok {
+		return 1
+	}
+	return clamp(math.Log10(float64(max(rank, 1))) / math.Log10(rareRank))
+}
+
+// Syllables estimates the number of syllables in word by counting vowel groups.
+func Syllables(word string) int {
+	w := letters(word)
+	n := 0
+	prevVowel := false
+	for i, r := range w {
+		vowel := isVowel(r) || (r == 'y' && i > 0)
+		if vowel && !prevVowel {
+			n++
+		}
+		prevVowel = vowel
+	}
+
+	// a final e is usually silent ("make"), but "-le" after a consonant is its own syllable ("table")
+	if strings.HasSuffix(w, "e") && !(strings.HasSuffix(w, "le") && len(w) > 2 && !isVowel(rune(w[len(w)-3]))) {
+		n--
+	}
+	// "-ed" only adds a syllable after t or d ("wanted", but "jumped")
+	if strings.HasSuffix(w, "ed") && !strings.HasSuffix(w, "ted") && !strings.HasSuffix(w, "ded") {
+		n--
+	}
+	return max(n, 1)
+}
+
+// silentPrefixes, silentSuffixes and silentInfixes start, end or sit inside words
+// and hold a letter that isn't pronounced.
+var (
+	silentPrefixes = []string{"kn", "gn", "wr", "ps", "pn", "pt", "rh", "wh"}
+	silentSuffixes = []string{"mb", "mn", "gn", "gh", "bt"}
+	silentInfixes  = []string{"stle", "ght", "lk", "lm", "dg", "tch", "sc"}
+)
+
+// SilentLetters counts the letter patterns in word that usually hide a silent letter,
+// such as the k in "knife", the b in "doubt" or the final e in "make".
+func SilentLetters(word string) int {
+	w := letters(word)
+	n := 0
+	for _, p := range silentPrefixes {
+		if strings.HasPrefix(w, p) && len(w) > len(p) {
+			n++
+			break
+		}
+	}
+	for _, p := range silentSuffixes {
+		if strings.HasSuffix(w, p) && len(w) > len(p) {
+			n++
+			break
+		}
+	}
+	for _, p := range silentInfixes {
+		n += strings.Count(w[1:], p)
+	}
+	// a final e after a consonant, other than in "-le", lengthens the vowel but isn't said
+	if len(w) > 3 && strings.HasSuffix(w, "e") && !isVowel(rune(w[len(w)-2])) && !strings.HasSuffix(w, "le") {
+		n++
+	}
+	return n
+}
+
+// trickyPatterns are spellings that don't follow from the sound, or are easy to get the wrong way round.
+var trickyPatterns = []string{"ph", "ough", "augh", "eigh", "eau", "ei", "ie", "ae", "oe", "rh", "ps", "sch", "que"}
+
+// rarePatterns counts rare letters, doubled consonants and tricky letter patterns in word.
+func rarePatterns(w string) int {
+	n := strings.Count(w, "j") + strings.Count(w, "q") + strings.Count(w, "x") + strings.Count(w, "z")
+	for _, p := range trickyPatterns {
+		n += strings.Count(w, p)
+	}
+	for i := 1; i < len(w); i++ {
+		if w[i] == w[i-1] && !isVowel(rune(w[i])) {
+			n++ // doubled consonants, as in "accommodate"
+		}
+	}
+	return n
+}
+
+// letters returns word in lower case with everything but the letters a to z removed.
+func letters(word string) string {
+	return strings.Map(func(r rune) rune {
+		if r >= 'A' && r <= 'Z' {
+			return r + 'a' - 'A'
+		}
+		if r >= 'a' && r <= 'z' {
+			return r
+		}
+		return -1
+	}, word)
+}
+
+func isVowel(r rune) bool {
+	return strings.ContainsRune("aeiou", r)
+}
+
+func clamp(x float64) float64 {
+	return min(max(x, 0), 1)
+}
@@ -0,0 +1,114 @@
+package difficulty_test
+
+import (
+	"testing"
+
+	"github.com/jharlan-hash/gospell/internal/difficulty"
+)
+
+func TestSyllables(t *testing.T) {
+	tests := []struct {
+		word string
+		want int
+	}{
+		{"a", 1},
+		{"cat", 1},
+		{"make", 1},
+		{"table", 2},
+		{"jumped", 1},
+		{"wanted", 2},
+		{"rhythm", 1},
+		{"happy", 2},
+		{"necessary", 4},
+		{"onomatopoeia", 5},
+	}
+	for _, tt := range tests {
+		t.Run(tt.word, func(t *testing.T) {
+			if got := difficulty.Syllables(tt.word); got != tt.want {
+				t.Errorf("Syllables(%q) = %d, want %d", tt.word, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSilentLetters(t *testing.T) {
+	tests := []struct {
+		word string
+		want int
+	}{
+		{"cat", 0},
+		{"knife", 2},
+		{"doubt", 1},
+		{"wrist", 1},
+		{"thumb", 1},
+		{"castle", 1},
+		{"table", 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.word, func(t *testing.T) {
+			if got := difficulty.SilentLetters(tt.word); got != tt.want {
+				t.Errorf("SilentLetters(%q) = %d, want %d", tt.word, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestScore_Order(t *testing.T) {
+	// each word should be harder than the one before
+	words := []string{"cat", "there", "knife", "necessary", "psychology", "accommodate"}
+	for i := 1; i < len(words); i++ {
+		easier, harder := difficulty.Score(words[i-1]), difficulty.Score(words[i])
+		if easier >= harder {
+			t.Errorf("Score(%q) = %g, want less than Score(%q) = %g", words[i-1], easier, words[i], harder)
+		}
+	}
+}
+
+func TestScore_Bounds(t *testing.T) {
+	for _, word := range []string{"", "a", "Mississippi", "antidisestablishmentarianism", "x-ray", "o'clock"} {
+		if got := difficulty.Score(word); got < 0 || got > difficulty.MaxScore {
+			t.Errorf("Score(%q) = %g, want between 0 and %d", word, got, difficulty.MaxScore)
+		}
+	}
+}
+
+// ranks is a Ranker backed by a map.
+type ranks map[string]int
+
+func (r ranks) Rank(word string) (int, bool) {
+	rank, ok := r[word]
+	return rank, ok
+}
+
+func TestScorer_Ranker(t *testing.T) {
+	s := difficulty.Scorer{Ranker: ranks{"the": 1, "abatis": 80_000}}
+
+	if common, plain := s.Score("the"), difficulty.Score("the"); common >= plain {
+		t.Errorf("Score(%q) with rank 1 = %g, want less than without a Ranker (%g)", "the", common, plain)
+	}
+	if rare, plain := s.Score("abatis"), difficulty.Score("abatis"); rare <= plain {
+		t.Errorf("Score(%q) with rank 80000 = %g, want more than without a Ranker (%g)", "abatis", rare, plain)
+	}
+	if missing, unranked := s.Score("syzygy"), (difficulty.Scorer{Ranker: ranks{"syzygy": 1_000_000}}).Score("syzygy"); missing != unranked {
+		t.Errorf("Score(%q) missing from the corpus = %g, want %g as for the rarest words", "syzygy", missing, unranked)
+	}
+}
+
+func TestLevels(t *testing.T) {
+	if difficulty.Levels[0].Min != 0 || difficulty.Levels[len(difficulty.Levels)-1].Max != difficulty.MaxScore {
+		t.Errorf("Levels = %v, want them to cover 0 to %d", difficulty.Levels, difficulty.MaxScore)
+	}
+	for i := 1; i < len(difficulty.Levels); i++ {
+		// scores have one decimal, so each level must start 0.1 after the last one ends
+		if gap := difficulty.Levels[i].Min - difficulty.Levels[i-1].Max; gap < 0.09 || gap > 0.11 {
+			t.Errorf("level %d ends at %g but level %d starts at %g", i, difficulty.Levels[i-1].Max, i+1, difficulty.Levels[i].Min)
+		}
+	}
+
+	if _, ok := difficulty.Level(0); ok {
+		t.Errorf("Level(0) ok = true, want false")
+	}
+	if r, ok := difficulty.Level(1); !ok || !r.Contains(difficulty.Score("cat")) {
+		t.Errorf("Level(1) = %v, %v, want a range containing %q", r, ok, "cat")
+	}
+}
@@ -0,0 +1,94 @@
+// Package homophone knows which words sound alike, like there, their and they're,
+// so that a spoken word can't be told from its homophones by ear alone.
+package homophone
+
+import (
+	_ "embed"
+	"fmt"
+	"strings"
+	"sync"
+)
+
+//go:embed homophones.txt
+var groupsFile string
+
+// Groups maps each word to the group of words that sound like it, the word included.
+type Groups map[string][]string
+
+// Embedded returns the curated homophone groups that ship with gospell.
+var Embedded = sync.OnceValue(func() Groups {
+	return Parse(groupsFile)
+})
+
+// Parse reads homophone groups, one group per line with its words separated by spaces.
+// Blank lines and lines starting with # are skipped.
+func Parse(text string) Groups {
+	g := make(Groups)
+	for _, line := range strings.Split(text, "\n") {
+		if strings.HasPrefix(strings.TrimSpace(line), "#") {
+			continue
+		}
+		group := strings.Fields(strings.ToLower(line))
+		if len(group) < 2 {
+			continue
+		}
+		for _, word := range group {
+			g[word] = group
+		}
+	}
+	return g
+}
+
+// Of returns the words that sound like word, not including word itself, or nil if there are none.
+func (g Groups) Of(word string) []string {
+	var others []string
+	for _, w := range g[word] {
+		if w != word {
+			others = append(others, w)
+		}
+	}
+	return others
+}
+
+// Has reports whether word sounds like another word.
+func (g Groups) Has(word string) bool {
+	return len(g[word]) > 0
+}
+
+// Alike reports whether a and b are different words that sound the same.
+func (g Groups) Alike(a, b string) bool {
+	if a == b {
+		return false
+	}
+	for _, w := range g[a] {
+		if w == b {
+			return true
+		}
+	}
+	return false
+}
+
+// Mode is how a session handles words that sound like other words.
+type Mode string
+
+const (
+	Define Mode = "define" // show a sentence or definition that tells the word apart, before the user answers
+	Accept Mode = "accept" // accept any word of the group as correct
+	Skip   Mode = "skip"   // leave homophones out of the session
+)
+
+// Modes lists every Mode, the default first.
+var Modes = []Mode{Define, Accept, Skip}
+
+// ParseMode returns the Mode named s, where the empty string is Define.
+func ParseMode(s string) (Mode, error) {
+	if s == "" {
+		return Define, nil
+	}
+	for _, m := range Modes {
+		if Mode(strings.ToLower(s)) == m {
+			return m, nil
+		}
+	}
+	return "", fmt.Errorf("unknown homophone mode %q, want define, accept or skip", s)
+}
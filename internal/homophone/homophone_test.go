@@ -0,0 +1,71 @@
+package homophone_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/jharlan-hash/gospell/internal/homophone"
+)
+
+func TestGroups(t *testing.T) {
+	g := homophone.Parse("# comment\nthere their they're\n\nto too two\nlonely\n")
+	tests := []struct {
+		word      string
+		wantOf    []string
+		wantAlike string // a word that sounds like word, if any
+	}{
+		{"there", []string{"their", "they're"}, "their"},
+		{"two", []string{"to", "too"}, "to"},
+		{"lonely", nil, ""},
+		{"cat", nil, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.word, func(t *testing.T) {
+			if got := g.Of(tt.word); !reflect.DeepEqual(got, tt.wantOf) {
+				t.Errorf("Of() = %v, want %v", got, tt.wantOf)
+			}
+			if got, want := g.Has(tt.word), tt.wantOf != nil; got != want {
+				t.Errorf("Has() = %v, want %v", got, want)
+			}
+			if tt.wantAlike != "" && !g.Alike(tt.word, tt.wantAlike) {
+				t.Errorf("Alike(%q, %q) = false, want true", tt.word, tt.wantAlike)
+			}
+			if g.Alike(tt.word, tt.word) {
+				t.Errorf("Alike(%q, %q) = true, want false for the same word", tt.word, tt.word)
+			}
+			if g.Alike(tt.word, "three") {
+				t.Errorf("Alike(%q, three) = true, want false", tt.word)
+			}
+		})
+	}
+}
+
+func TestEmbedded(t *testing.T) {
+	g := homophone.Embedded()
+	for _, pair := range [][2]string{{"there", "their"}, {"their", "they're"}, {"principal", "principle"}, {"knight", "night"}} {
+		if !g.Alike(pair[0], pair[1]) {
+			t.Errorf("Alike(%q, %q) = false, want true", pair[0], pair[1])
+		}
+	}
+}
+
+func TestParseMode(t *testing.T) {
+	tests := []struct {
+		s       string
+		want    homophone.Mode
+		wantErr bool
+	}{
+		{"", homophone.Define, false},
+		{"accept", homophone.Accept, false},
+		{"SKIP", homophone.Skip, false},
+		{"guess", "", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.s, func(t *testing.T) {
+			got, err := homophone.ParseMode(tt.s)
+			if (err != nil) != tt.wantErr || got != tt.want {
+				t.Errorf("ParseMode() = %q, %v, want %q, error %v", got, err, tt.want, tt.wantErr)
+			}
+		})
+	}
+}
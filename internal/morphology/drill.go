@@ -0,0 +1,73 @@
+package morphology
+
+import "github.com/jharlan-hash/gospell/internal/api"
+
+// Drill is an api.WordSource that serves whole families in a row: a lemma, then each of its forms.
+type Drill struct {
+	Lemmas api.WordSource // picks the next family by its lemma
+
+	families map[string]Family
+	forms    map[string]Form // by word
+	size     int
+	queue    []string // rest of the current family
+}
+
+// NewDrill returns a Drill of families, picked by lemmas.
+// Lemmas that aren't the lemma of one of the families are served on their own.
+func NewDrill(families []Family, lemmas api.WordSource) *Drill {
+	d := &Drill{Lemmas: lemmas, families: make(map[string]Family), forms: make(map[string]Form)}
+	for _, fam := range families {
+		d.families[fam.Lemma] = fam
+		d.forms[fam.Lemma] = Form{Word: fam.Lemma, Lemma: fam.Lemma, Kind: Base}
+		for _, f := range fam.Forms {
+			d.forms[f.Word] = f
+		}
+		d.size += len(fam.Forms) + 1
+	}
+	return d
+}
+
+// Lemmas returns the lemmas of families.
+func Lemmas(families []Family) []string {
+	lemmas := make([]string, len(families))
+	for i, fam := range families {
+		lemmas[i] = fam.Lemma
+	}
+	return lemmas
+}
+
+// Next returns the next form of the current family, or the lemma of the next one.
+func (d *Drill) Next() string {
+	if len(d.queue) == 0 {
+		lemma := d.Lemmas.Next()
+		d.queue = []string{lemma}
+		if fam, ok := d.families[lemma]; ok {
+			d.queue = fam.Words()
+		}
+	}
+	word := d.queue[0]
+	d.queue = d.queue[1:]
+	return word
+}
+
+// Form returns the form word was served as, to prompt for it.
+func (d *Drill) Form(word string) (Form, bool) {
+	f, ok := d.forms[word]
+	return f, ok
+}
+
+// Len returns the number of words across all families.
+func (d *Drill) Len() int {
+	return d.size
+}
+
+func (d *Drill) Reset() {
+	d.Lemmas.Reset()
+	d.queue = nil
+}
+
+// Seed seeds the choice of families.
+func (d *Drill) Seed(seed int64) {
+	api.Seed(d.Lemmas, seed)
+	d.Reset()
+}
@@ -0,0 +1,371 @@
+// Package morphology groups words into inflection families, like hope, hopes, hoped and hoping,
+// and drills the forms of a family in a row.
+//
+// Forms are found by applying the regular English spelling rules for each suffix to a lemma
+// and keeping the candidates the word list contains, so irregular forms (sing, sang) are never paired up.
+// Every form records the Rule it was spelled with, which is what learners get wrong:
+// doubling the final consonant, dropping the silent e, changing y to i and so on.
+package morphology
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/jharlan-hash/gospell/internal/difficulty"
+)
+
+// Kind is a form of a word.
+type Kind string
+
+const (
+	Base        Kind = "base"        // the lemma itself
+	S           Kind = "s"           // plural of a noun, or the he/she/it form of a verb
+	Past        Kind = "past"        // past tense, -ed
+	Participle  Kind = "participle"  // present participle, -ing
+	Comparative Kind = "comparative" // -er
+	Superlative Kind = "superlative" // -est
+	Ment        Kind = "ment"        // noun ending in -ment
+)
+
+// Kinds lists the inflected kinds in the order they are drilled.
+var Kinds = []Kind{S, Past, Participle, Comparative, Superlative, Ment}
+
+// suffixes are the suffixes of each kind, as named in prompts and hints.
+var suffixes = map[Kind]string{
+	S:           "s",
+	Past:        "ed",
+	Participle:  "ing",
+	Comparative: "er",
+	Superlative: "est",
+	Ment:        "ment",
+}
+
+// Rule is the spelling change made to the lemma when adding a suffix.
+type Rule string
+
+const (
+	Plain  Rule = ""        // the suffix is simply appended: walk, walked
+	Double Rule = "double"  // the final consonant is doubled: hop, hopped
+	DropE  Rule = "drop-e"  // the silent e is dropped: hope, hoping
+	KeepE  Rule = "keep-e"  // the silent e stays before a consonant: excite, excitement
+	YToI   Rule = "y-to-i"  // y after a consonant becomes i: carry, carried
+	IEToY  Rule = "ie-to-y" // ie becomes y before -ing: die, dying
+	AddES  Rule = "add-es"  // -es rather than -s: box, boxes
+	AddK   Rule = "add-k"   // a final c takes a k: picnic, picnicked
+)
+
+// Form is an inflected form of a lemma.
+type Form struct {
+	Word         string
+	Lemma        string
+	Kind         Kind
+	Rule         Rule
+	PartOfSpeech string // "noun" or "verb" if it tells which S form is meant, otherwise empty
+}
+
+// Prompt returns what the learner is asked to spell, e.g. "Spell the past tense of hop".
+// The base form has no prompt.
+func (f Form) Prompt() string {
+	switch f.Kind {
+	case S:
+		switch f.PartOfSpeech {
+		case "noun":
+			return "Spell the plural of " + f.Lemma
+		case "verb":
+			return fmt.Sprintf("Spell the form of %s that goes with he, she or it", f.Lemma)
+		}
+		return fmt.Sprintf("Spell the -s form of %s", f.Lemma)
+	case Past:
+		return "Spell the past tense of " + f.Lemma
+	case Participle:
+		return fmt.Sprintf("Spell the -ing form of %s", f.Lemma)
+	case Comparative:
+		return "Spell the comparative of " + f.Lemma
+	case Superlative:
+		return "Spell the superlative of " + f.Lemma
+	case Ment:
+		return fmt.Sprintf("Spell the noun of %s ending in -ment", f.Lemma)
+	}
+	return ""
+}
+
+// Hint explains the spelling rule of the form, e.g. "double the p before -ed", or returns "" for Plain forms.
+func (f Form) Hint() string {
+	suffix := suffixes[f.Kind]
+	switch f.Rule {
+	case Double:
+		return fmt.Sprintf("double the %c before -%s", f.Lemma[len(f.Lemma)-1], suffix)
+	case DropE:
+		return fmt.Sprintf("drop the silent e before -%s", suffix)
+	case KeepE:
+		return fmt.Sprintf("keep the silent e before -%s", suffix)
+	case YToI:
+		return fmt.Sprintf("change the y to i before -%s", suffix)
+	case IEToY:
+		return "change ie to y before -ing"
+	case AddES:
+		return "add -es, not just -s"
+	case AddK:
+		return fmt.Sprintf("add a k after the c before -%s", suffix)
+	}
+	return ""
+}
+
+// Family is a lemma and its inflected forms.
+type Family struct {
+	Lemma string
+	Forms []Form // in the order of Kinds
+}
+
+// Words returns the lemma followed by its forms.
+func (f Family) Words() []string {
+	words := []string{f.Lemma}
+	for _, form := range f.Forms {
+		words = append(words, form.Word)
+	}
+	return words
+}
+
+// Tagger reports the parts of speech of a word, e.g. "noun" and "verb". dictionary.Dictionary implements it.
+type Tagger interface {
+	PartsOfSpeech(word string) []string
+}
+
+const (
+	// MinLemma is the length of the shortest lemma, which keeps out pairs like a, as.
+	MinLemma = 3
+	// MinForms is how many forms a lemma needs to make a family worth drilling.
+	MinForms = 2
+)
+
+// Group returns the families of words with at least MinForms forms, sorted by lemma.
+// The tagger is optional; it tells plurals from verb forms and spots adjectives.
+// A word that could be a form of several lemmas, like singed of sing and singe,
+// belongs to the lemma whose rule changes its spelling.
+func Group(words []string, tagger Tagger) []Family {
+	set := make(map[string]bool, len(words))
+	for _, w := range words {
+		set[w] = true
+	}
+	lemmas := make([]string, 0, len(set))
+	for w := range set {
+		if len(w) >= MinLemma && isLower(w) {
+			lemmas = append(lemmas, w)
+		}
+	}
+	slices.Sort(lemmas)
+
+	claims := make(map[string]Form) // by word, the lemma it belongs to
+	candidates := make(map[string][]Form)
+	for _, lemma := range lemmas {
+		var pos []string
+		if tagger != nil {
+			pos = tagger.PartsOfSpeech(lemma)
+		}
+		forms := formsOf(lemma, set, pos)
+		for _, f := range forms {
+			if prev, ok := claims[f.Word]; !ok || prev.Rule == Plain && f.Rule != Plain {
+				claims[f.Word] = f
+			}
+		}
+		candidates[lemma] = forms
+	}
+
+	var families []Family
+	for _, lemma := range lemmas {
+		fam := Family{Lemma: lemma}
+		for _, f := range candidates[lemma] {
+			if claims[f.Word].Lemma == lemma {
+				fam.Forms = append(fam.Forms, f)
+			}
+		}
+		if len(fam.Forms) >= MinForms {
+			families = append(families, fam)
+		}
+	}
+	return families
+}
+
+// formsOf returns the forms of lemma that are in words, in the order of Kinds.
+func formsOf(lemma string, words map[string]bool, pos []string) []Form {
+	byKind := make(map[Kind]Form)
+	for _, kind := range Kinds {
+		for _, f := range inflect(lemma, kind) {
+			if words[f.Word] && f.Word != lemma {
+				byKind[kind] = f
+				break
+			}
+		}
+	}
+
+	// -er and -est also make nouns (bake, baker), so an adjective needs both or a dictionary saying so
+	adjective := slices.Contains(pos, "adjective")
+	_, er := byKind[Comparative]
+	_, est := byKind[Superlative]
+	if !adjective && !(er && est) {
+		delete(byKind, Comparative)
+		delete(byKind, Superlative)
+	}
+	// likewise -ment makes nouns from verbs only (seg, segment)
+	_, past := byKind[Past]
+	_, participle := byKind[Participle]
+	if !past && !participle {
+		delete(byKind, Ment)
+	}
+
+	if f, ok := byKind[S]; ok {
+		noun, verb := slices.Contains(pos, "noun"), slices.Contains(pos, "verb")
+		switch {
+		case noun && !verb:
+			f.PartOfSpeech = "noun"
+		case verb && !noun:
+			f.PartOfSpeech = "verb"
+		}
+		byKind[S] = f
+	}
+
+	var forms []Form
+	for _, kind := range Kinds {
+		if f, ok := byKind[kind]; ok {
+			forms = append(forms, f)
+		}
+	}
+	return forms
+}
+
+// inflect returns the possible spellings of lemma with the suffix of kind, the likeliest first.
+// Which one is right can depend on stress (visited, but committed), so the word list decides.
+func inflect(lemma string, kind Kind) []Form {
+	var forms []Form
+	add := func(word string, rule Rule) {
+		forms = append(forms, Form{Word: word, Lemma: lemma, Kind: kind, Rule: rule})
+	}
+	n := len(lemma)
+	stem := lemma[:n-1]
+
+	switch kind {
+	case S:
+		switch {
+		case consonantY(lemma):
+			add(stem+"ies", YToI)
+		case hissing(lemma):
+			add(lemma+"es", AddES)
+		case lemma[n-1] == 'o' && !isVowel(lemma[n-2]):
+			add(lemma+"es", AddES) // potatoes, but pianos
+			add(lemma+"s", Plain)
+		default:
+			add(lemma+"s", Plain)
+		}
+
+	case Past, Participle:
+		suffix := suffixes[kind]
+		switch {
+		case kind == Participle && strings.HasSuffix(lemma, "ie"):
+			add(lemma[:n-2]+"ying", IEToY)
+		case kind == Past && lemma[n-1] == 'e':
+			if silentE(lemma) {
+				add(lemma+"d", DropE)
+			} else {
+				add(lemma+"d", Plain) // agreed, died
+			}
+		case silentE(lemma) && strings.HasSuffix(lemma, "nge") && kind == Participle:
+			add(lemma+suffix, KeepE) // singeing, to tell it from singing, but changing
+			add(stem+suffix, DropE)
+		case silentE(lemma):
+			add(stem+suffix, DropE)
+		case kind == Past && consonantY(lemma):
+			add(stem+"ied", YToI)
+		case strings.HasSuffix(lemma, "ic"):
+			add(lemma+"k"+suffix, AddK)
+			add(lemma+suffix, Plain)
+		default:
+			addDoubled(lemma, suffix, add)
+		}
+
+	case Comparative, Superlative:
+		suffix := suffixes[kind]
+		switch {
+		case lemma[n-1] == 'e':
+			if silentE(lemma) {
+				add(lemma+suffix[1:], DropE)
+			} else {
+				add(lemma+suffix[1:], Plain) // freer
+			}
+		case consonantY(lemma):
+			add(stem+"i"+suffix, YToI)
+		default:
+			addDoubled(lemma, suffix, add)
+		}
+
+	case Ment:
+		switch {
+		case silentE(lemma):
+			add(lemma+"ment", KeepE)
+			add(stem+"ment", DropE) // argument, judgment
+		case consonantY(lemma):
+			add(stem+"iment", YToI) // merriment
+		default:
+			add(lemma+"ment", Plain)
+		}
+	}
+	return forms
+}
+
+// addDoubled adds lemma with a vowel suffix, doubling the final consonant where English may.
+// One-syllable words always double (hop, hopped); longer ones only when the last syllable is stressed,
+// which spelling doesn't show, so both are tried.
+func addDoubled(lemma, suffix string, add func(string, Rule)) {
+	if !endsCVC(lemma) {
+		add(lemma+suffix, Plain)
+		return
+	}
+	doubled := lemma + lemma[len(lemma)-1:] + suffix
+	if difficulty.Syllables(lemma) == 1 {
+		add(doubled, Double)
+		return
+	}
+	add(lemma+suffix, Plain)
+	add(doubled, Double)
+}
+
+// endsCVC reports whether word ends in a consonant, a single vowel and a consonant other than w, x or y,
+// the ending whose consonant doubles. The u of qu counts as a consonant: quit, quitting.
+func endsCVC(word string) bool {
+	n := len(word)
+	if n < 3 || isVowel(word[n-1]) || strings.IndexByte("wxy", word[n-1]) >= 0 || !isVowel(word[n-2]) {
+		return false
+	}
+	return !isVowel(word[n-3]) || n >= 4 && word[n-4:n-2] == "qu"
+}
+
+// silentE reports whether word ends in a silent e that a vowel suffix replaces: hope, argue, but not agree, toe or dye.
+func silentE(word string) bool {
+	n := len(word)
+	return n >= 3 && word[n-1] == 'e' && strings.IndexByte("aeioy", word[n-2]) < 0
+}
+
+// consonantY reports whether word ends in a y after a consonant: carry, but not play.
+func consonantY(word string) bool {
+	n := len(word)
+	return word[n-1] == 'y' && !isVowel(word[n-2])
+}
+
+// hissing reports whether word ends in a sound that takes -es: s, x, z, ch or sh.
+func hissing(word string) bool {
+	return strings.HasSuffix(word, "s") || strings.HasSuffix(word, "x") || strings.HasSuffix(word, "z") ||
+		strings.HasSuffix(word, "ch") || strings.HasSuffix(word, "sh")
+}
+
+func isVowel(b byte) bool {
+	return strings.IndexByte("aeiou", b) >= 0
+}
+
+func isLower(word string) bool {
+	for i := range len(word) {
+		if word[i] < 'a' || word[i] > 'z' {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,202 @@
+package morphology_test
+
+import (
+	"reflect"
+	"slices"
+	"testing"
+
+	"github.com/jharlan-hash/gospell/internal/api"
+	"github.com/jharlan-hash/gospell/internal/morphology"
+)
+
+// tagger is a morphology.Tagger backed by a map.
+type tagger map[string][]string
+
+func (t tagger) PartsOfSpeech(word string) []string {
+	return t[word]
+}
+
+// words holds families showing each spelling rule, and some words that only look related.
+var words = []string{
+	"abandon", "abandons", "abandoned", "abandoning", "abandonment",
+	"hop", "hops", "hopped", "hopping",
+	"hope", "hopes", "hoped", "hoping",
+	"visit", "visits", "visited", "visiting",
+	"commit", "commits", "committed", "committing", "commitment",
+	"carry", "carries", "carried", "carrying",
+	"die", "dies", "died", "dying",
+	"box", "boxes", "boxed", "boxing",
+	"panic", "panics", "panicked", "panicking",
+	"big", "bigger", "biggest",
+	"happy", "happier", "happiest",
+	"excite", "excites", "excited", "exciting", "excitement",
+	"sing", "sings", "singing",
+	"singe", "singes", "singed", "singeing",
+	"bake", "baker", // a noun, not a comparative
+	"seg", "segment", // not a verb
+	"cat", "cats", // a single form is no family
+}
+
+func TestGroup(t *testing.T) {
+	tests := []struct {
+		lemma string
+		want  map[string]morphology.Rule // forms and their rules
+	}{
+		{"abandon", map[string]morphology.Rule{"abandons": morphology.Plain, "abandoned": morphology.Plain, "abandoning": morphology.Plain, "abandonment": morphology.Plain}},
+		{"hop", map[string]morphology.Rule{"hops": morphology.Plain, "hopped": morphology.Double, "hopping": morphology.Double}},
+		{"hope", map[string]morphology.Rule{"hopes": morphology.Plain, "hoped": morphology.DropE, "hoping": morphology.DropE}},
+		{"visit", map[string]morphology.Rule{"visits": morphology.Plain, "visited": morphology.Plain, "visiting": morphology.Plain}},
+		{"commit", map[string]morphology.Rule{"commits": morphology.Plain, "committed": morphology.Double, "committing": morphology.Double, "commitment": morphology.Plain}},
+		{"carry", map[string]morphology.Rule{"carries": morphology.YToI, "carried": morphology.YToI, "carrying": morphology.Plain}},
+		{"die", map[string]morphology.Rule{"dies": morphology.Plain, "died": morphology.Plain, "dying": morphology.IEToY}},
+		{"box", map[string]morphology.Rule{"boxes": morphology.AddES, "boxed": morphology.Plain, "boxing": morphology.Plain}},
+		{"panic", map[string]morphology.Rule{"panics": morphology.Plain, "panicked": morphology.AddK, "panicking": morphology.AddK}},
+		{"big", map[string]morphology.Rule{"bigger": morphology.Double, "biggest": morphology.Double}},
+		{"happy", map[string]morphology.Rule{"happier": morphology.YToI, "happiest": morphology.YToI}},
+		{"excite", map[string]morphology.Rule{"excites": morphology.Plain, "excited": morphology.DropE, "exciting": morphology.DropE, "excitement": morphology.KeepE}},
+		{"sing", map[string]morphology.Rule{"sings": morphology.Plain, "singing": morphology.Plain}},
+		{"singe", map[string]morphology.Rule{"singes": morphology.Plain, "singed": morphology.DropE, "singeing": morphology.KeepE}},
+		{"bake", nil},
+		{"seg", nil},
+		{"cat", nil},
+	}
+
+	families := make(map[string]morphology.Family)
+	for _, fam := range morphology.Group(words, nil) {
+		families[fam.Lemma] = fam
+	}
+	for _, tt := range tests {
+		t.Run(tt.lemma, func(t *testing.T) {
+			fam, ok := families[tt.lemma]
+			if tt.want == nil {
+				if ok {
+					t.Errorf("Group() made a family of %s: %+v", tt.lemma, fam.Forms)
+				}
+				return
+			}
+
+			got := make(map[string]morphology.Rule)
+			for _, f := range fam.Forms {
+				got[f.Word] = f.Rule
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("forms of %s = %v, want %v", tt.lemma, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestGroup_Tagger(t *testing.T) {
+	tags := tagger{
+		"box":   {"noun", "verb"},
+		"hop":   {"verb"},
+		"panic": {"noun"},
+	}
+	families := make(map[string]morphology.Family)
+	for _, fam := range morphology.Group(words, tags) {
+		families[fam.Lemma] = fam
+	}
+
+	tests := []struct {
+		lemma string
+		want  string
+	}{
+		{"hop", "Spell the form of hop that goes with he, she or it"},
+		{"panic", "Spell the plural of panic"},
+		{"box", "Spell the -s form of box"}, // could be either
+		{"hope", "Spell the -s form of hope"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.lemma, func(t *testing.T) {
+			if got := families[tt.lemma].Forms[0].Prompt(); got != tt.want {
+				t.Errorf("Prompt() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestForm_Hint(t *testing.T) {
+	tests := []struct {
+		form morphology.Form
+		want string
+	}{
+		{morphology.Form{Word: "hopped", Lemma: "hop", Kind: morphology.Past, Rule: morphology.Double}, "double the p before -ed"},
+		{morphology.Form{Word: "hoping", Lemma: "hope", Kind: morphology.Participle, Rule: morphology.DropE}, "drop the silent e before -ing"},
+		{morphology.Form{Word: "excitement", Lemma: "excite", Kind: morphology.Ment, Rule: morphology.KeepE}, "keep the silent e before -ment"},
+		{morphology.Form{Word: "happier", Lemma: "happy", Kind: morphology.Comparative, Rule: morphology.YToI}, "change the y to i before -er"},
+		{morphology.Form{Word: "walked", Lemma: "walk", Kind: morphology.Past, Rule: morphology.Plain}, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.form.Word, func(t *testing.T) {
+			if got := tt.form.Hint(); got != tt.want {
+				t.Errorf("Hint() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDrill(t *testing.T) {
+	families := morphology.Group(words, nil)
+	d := morphology.NewDrill(families, api.NewList(morphology.Lemmas(families)))
+	d.Seed(1)
+
+	// every lemma is followed by all of its forms, in order
+	for range 20 {
+		lemma := d.Next()
+		var fam morphology.Family
+		for _, f := range families {
+			if f.Lemma == lemma {
+				fam = f
+			}
+		}
+		if fam.Lemma == "" {
+			t.Fatalf("Next() = %q, want the lemma of a family", lemma)
+		}
+		for _, want := range fam.Words()[1:] {
+			if got := d.Next(); got != want {
+				t.Fatalf("after %s Next() = %q, want %q", lemma, got, want)
+			}
+			form, ok := d.Form(want)
+			if !ok || form.Lemma != lemma || form.Prompt() == "" {
+				t.Errorf("Form(%q) = %+v, want a form of %s with a prompt", want, form, lemma)
+			}
+		}
+	}
+
+	if form, _ := d.Form("hope"); form.Kind != morphology.Base || form.Prompt() != "" {
+		t.Errorf("Form(hope) = %+v, want the base form without a prompt", form)
+	}
+}
+
+func TestVariants(t *testing.T) {
+	tests := []struct {
+		word    string
+		want    []string // among the variants
+		notWant []string
+	}{
+		{"hopped", []string{"hopped", "hop", "hops", "hopping"}, []string{"hope", "hoping"}},
+		{"hoping", []string{"hope", "hopes", "hoped"}, []string{"hop", "hopped"}},
+		{"carry", []string{"carries", "carried", "carrying"}, nil},
+		{"dying", []string{"die", "dies", "died"}, nil},
+		{"panicked", []string{"panic", "panicking"}, nil},
+		{"excitement", []string{"excite", "excited", "exciting"}, nil},
+		{"Happiest", []string{"happiest", "happy", "happier"}, nil},
+		{"cat", []string{"cat", "cats"}, []string{"ca"}},
+		{"don't", []string{"don't"}, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.word, func(t *testing.T) {
+			got := morphology.Variants(tt.word)
+			for _, w := range tt.want {
+				if !slices.Contains(got, w) {
+					t.Errorf("Variants() = %v, want %q among them", got, w)
+				}
+			}
+			for _, w := range tt.notWant {
+				if slices.Contains(got, w) {
+					t.Errorf("Variants() = %v, want no %q", got, w)
+				}
+			}
+		})
+	}
+}
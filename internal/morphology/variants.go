@@ -0,0 +1,97 @@
+package morphology
+
+import (
+	"regexp"
+	"slices"
+	"strings"
+)
+
+// maxSuffix is the length of the longest suffix inflect adds, with its spelling change: -iment, as in merriment.
+const maxSuffix = len("iment")
+
+// Variants returns word, the lemmas it may be a regular form of and every regular form of those,
+// e.g. hopped gives hopped, hop, hops, hopping and so on. Unlike Group it needs no word list,
+// so it includes misspellings a word list would rule out, like both visited and visitted.
+func Variants(word string) []string {
+	word = strings.ToLower(word)
+	variants := []string{word}
+	if len(word) < MinLemma || !isLower(word) {
+		return variants
+	}
+
+	seen := map[string]bool{word: true}
+	for _, lemma := range append([]string{word}, lemmasOf(word)...) {
+		if !seen[lemma] {
+			seen[lemma] = true
+			variants = append(variants, lemma)
+		}
+		for _, kind := range Kinds {
+			for _, f := range inflect(lemma, kind) {
+				if !seen[f.Word] {
+					seen[f.Word] = true
+					variants = append(variants, f.Word)
+				}
+			}
+		}
+	}
+	return variants
+}
+
+// Analyze returns the ways word may be a regular form of a lemma that known reports true for,
+// e.g. hopped as the past tense of hop. A nil known accepts any lemma.
+// Word lists and dictionaries like WordNet often list only lemmas; this finds the lemma of the other words.
+func Analyze(word string, known func(lemma string) bool) []Form {
+	if len(word) < MinLemma || !isLower(word) {
+		return nil
+	}
+	var forms []Form
+	for _, lemma := range candidateLemmas(word) {
+		if known != nil && !known(lemma) {
+			continue
+		}
+		for _, kind := range Kinds {
+			for _, f := range inflect(lemma, kind) {
+				if f.Word == word {
+					forms = append(forms, f)
+				}
+			}
+		}
+	}
+	return forms
+}
+
+// lemmasOf returns the words that word is a regular form of.
+func lemmasOf(word string) []string {
+	var lemmas []string
+	for _, f := range Analyze(word, nil) {
+		if !slices.Contains(lemmas, f.Lemma) {
+			lemmas = append(lemmas, f.Lemma)
+		}
+	}
+	return lemmas
+}
+
+// candidateLemmas returns the words that word could be a form of, by trying every way of undoing a suffix:
+// cutting it off (walked), and putting back an e (hoped), a y (carried) or an ie (dying).
+// Most aren't, like hopp for hopped; Analyze checks which are.
+func candidateLemmas(word string) []string {
+	var lemmas []string
+	for cut := 1; cut <= maxSuffix && len(word)-cut >= MinLemma-2; cut++ {
+		stem := word[:len(word)-cut]
+		for _, lemma := range []string{stem, stem + "e", stem + "y", stem + "ie"} {
+			if len(lemma) >= MinLemma && lemma != word && !slices.Contains(lemmas, lemma) {
+				lemmas = append(lemmas, lemma)
+			}
+		}
+	}
+	return lemmas
+}
+
+// Matcher returns a regexp that matches word and its Variants as whole words, ignoring case.
+func Matcher(word string) *regexp.Regexp {
+	variants := Variants(word)
+	for i, v := range variants {
+		variants[i] = regexp.QuoteMeta(v)
+	}
+	return regexp.MustCompile(`(?i)\b(?:` + strings.Join(variants, "|") + `)\b`)
+}
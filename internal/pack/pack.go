@@ -0,0 +1,308 @@
+// Package pack reads themed word packs.
+//
+// A pack is a directory with a pack.json manifest next to a word list and, optionally,
+// recorded pronunciations:
+//
+//	medical-terms/
+//	    pack.json
+//	    words.csv
+//	    audio/colonel.mp3
+//
+// The manifest names the pack and points at the other files:
+//
+//	{
+//	  "format": 1,
+//	  "name": "medical-terms",
+//	  "title": "Medical terms",
+//	  "version": "1.0.0",
+//	  "words": "words.csv",
+//	  "audio": {"colonel": "audio/colonel.mp3"},
+//	  "tags": ["medicine"]
+//	}
+//
+// The word list is in any format api.ReadEntries reads, chosen by its extension,
+// so it can carry definitions, sentences and tags of its own.
+// Some packs are embedded in gospell; more can be installed into a directory of packs.
+package pack
+
+import (
+	"embed"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	"sort"
+
+	"github.com/jharlan-hash/gospell/internal/api"
+)
+
+// FormatVersion is the newest manifest format this version of gospell reads.
+const FormatVersion = 1
+
+// ManifestFile is the name of the manifest in a pack directory.
+const ManifestFile = "pack.json"
+
+// Manifest describes a pack.
+type Manifest struct {
+	Format      int               `json:"format"`                // manifest format, see FormatVersion
+	Name        string            `json:"name"`                  // short name used with --pack, e.g. "sat-vocab"
+	Title       string            `json:"title"`                 // human-readable name
+	Version     string            `json:"version"`               // version of the pack's contents
+	Description string            `json:"description,omitempty"` // a sentence on what the pack is for
+	Words       string            `json:"words"`                 // path of the word list within the pack
+	Audio       map[string]string `json:"audio,omitempty"`       // paths of recorded pronunciations within the pack, by word
+	Tags        []string          `json:"tags,omitempty"`        // added to the tags of every word
+}
+
+// namePattern is what pack names look like, so that they are safe as directory names.
+var namePattern = regexp.MustCompile(`^[a-z0-9][a-z0-9-]*$`)
+
+// Validate reports whether the manifest is complete and readable by this version of gospell.
+func (m *Manifest) Validate() error {
+	switch {
+	case m.Format < 1:
+		return errors.New("missing format version")
+	case m.Format > FormatVersion:
+		return fmt.Errorf("format %d needs a newer gospell, this one reads up to format %d", m.Format, FormatVersion)
+	case !namePattern.MatchString(m.Name):
+		return fmt.Errorf("name %q must be lower-case letters, digits and dashes", m.Name)
+	case !fs.ValidPath(m.Words) || m.Words == ".":
+		return fmt.Errorf("words %q must be a path within the pack", m.Words)
+	}
+	for word, clip := range m.Audio {
+		if !fs.ValidPath(clip) || clip == "." {
+			return fmt.Errorf("audio for %q: %q must be a path within the pack", word, clip)
+		}
+	}
+	return nil
+}
+
+// Pack is a word pack and the files it is read from.
+type Pack struct {
+	Manifest
+	Source string // where the pack comes from: "embedded" or its directory
+	fsys   fs.FS
+}
+
+// Open reads the pack whose manifest is at the root of fsys.
+// Source says where fsys comes from, for messages.
+func Open(fsys fs.FS, source string) (*Pack, error) {
+	b, err := fs.ReadFile(fsys, ManifestFile)
+	if err != nil {
+		return nil, fmt.Errorf("pack %s: %w", source, err)
+	}
+	var m Manifest
+	if err := json.Unmarshal(b, &m); err != nil {
+		return nil, fmt.Errorf("pack %s: %w", source, err)
+	}
+	if err := m.Validate(); err != nil {
+		return nil, fmt.Errorf("pack %s: %w", source, err)
+	}
+	return &Pack{Manifest: m, Source: source, fsys: fsys}, nil
+}
+
+// Entries returns the words of the pack, each tagged with the pack's tags.
+func (p *Pack) Entries() ([]api.Entry, error) {
+	f, err := p.fsys.Open(p.Words)
+	if err != nil {
+		return nil, fmt.Errorf("pack %s: %w", p.Name, err)
+	}
+	defer f.Close()
+
+	entries, err := api.ReadEntries(f, api.FormatOf(p.Words))
+	if err != nil {
+		return nil, fmt.Errorf("pack %s: reading %s: %w", p.Name, p.Words, err)
+	}
+	for i := range entries {
+		entries[i].Tags = append(entries[i].Tags, p.Tags...)
+	}
+	return entries, nil
+}
+
+// Recordings returns the recorded pronunciations of the pack, by word.
+func (p *Pack) Recordings() (map[string][]byte, error) {
+	clips := make(map[string][]byte, len(p.Audio))
+	for word, name := range p.Audio {
+		clip, err := fs.ReadFile(p.fsys, name)
+		if err != nil {
+			return nil, fmt.Errorf("pack %s: audio for %q: %w", p.Name, word, err)
+		}
+		clips[word] = clip
+	}
+	return clips, nil
+}
+
+// check reads everything the pack refers to, to catch broken packs before they are used.
+func (p *Pack) check() error {
+	entries, err := p.Entries()
+	if err != nil {
+		return err
+	}
+	if len(entries) == 0 {
+		return fmt.Errorf("pack %s: %s has no words", p.Name, p.Words)
+	}
+	_, err = p.Recordings()
+	return err
+}
+
+//go:embed packs
+var embedded embed.FS
+
+// Embedded returns the packs that ship with gospell, sorted by name.
+func Embedded() ([]*Pack, error) {
+	dirs, err := fs.ReadDir(embedded, "packs")
+	if err != nil {
+		return nil, err
+	}
+
+	var packs []*Pack
+	for _, dir := range dirs {
+		sub, err := fs.Sub(embedded, path.Join("packs", dir.Name()))
+		if err != nil {
+			return nil, err
+		}
+		p, err := Open(sub, "embedded")
+		if err != nil {
+			return nil, err
+		}
+		packs = append(packs, p)
+	}
+	return packs, nil
+}
+
+// DefaultDir returns where packs are installed unless the config says otherwise,
+// e.g. $XDG_CONFIG_HOME/gospell/packs on Linux.
+func DefaultDir() (string, error) {
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "gospell", "packs"), nil
+}
+
+// BrokenError is an installed pack that can't be read.
+type BrokenError struct {
+	Dir string // the pack's directory
+	Err error
+}
+
+func (e *BrokenError) Error() string { return e.Err.Error() }
+func (e *BrokenError) Unwrap() error { return e.Err }
+
+// Installed returns the packs installed in dir, sorted by name. A missing dir has no packs.
+// Packs that can't be read are left out and returned as broken, so one bad pack doesn't hide the others.
+func Installed(dir string) (packs []*Pack, broken []*BrokenError, err error) {
+	entries, err := os.ReadDir(dir)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil, nil
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+
+	for _, e := range entries {
+		if !e.IsDir() || e.Name()[0] == '.' {
+			continue // leftovers of an interrupted install start with a dot
+		}
+		packDir := filepath.Join(dir, e.Name())
+		p, err := Open(os.DirFS(packDir), packDir)
+		if err != nil {
+			broken = append(broken, &BrokenError{Dir: packDir, Err: err})
+			continue
+		}
+		packs = append(packs, p)
+	}
+	return packs, broken, nil
+}
+
+// All returns the embedded packs and those installed in dir, sorted by name, and the installed packs that are broken.
+// An installed pack replaces an embedded one of the same name.
+func All(dir string) (packs []*Pack, broken []*BrokenError, err error) {
+	embedded, err := Embedded()
+	if err != nil {
+		return nil, nil, err
+	}
+	installed, broken, err := Installed(dir)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	byName := make(map[string]*Pack)
+	for _, p := range append(embedded, installed...) {
+		byName[p.Name] = p
+	}
+	packs = make([]*Pack, 0, len(byName))
+	for _, p := range byName {
+		packs = append(packs, p)
+	}
+	sort.Slice(packs, func(i, j int) bool { return packs[i].Name < packs[j].Name })
+	return packs, broken, nil
+}
+
+// Find returns the pack called name from All.
+// If there is none but a broken pack is installed under that name, it returns why that pack can't be read.
+func Find(name, dir string) (*Pack, error) {
+	packs, broken, err := All(dir)
+	if err != nil {
+		return nil, err
+	}
+	for _, p := range packs {
+		if p.Name == name {
+			return p, nil
+		}
+	}
+	for _, b := range broken {
+		if filepath.Base(b.Dir) == name {
+			return nil, b
+		}
+	}
+	return nil, fmt.Errorf("no pack called %q, see gospell packs list", name)
+}
+
+// Install copies the pack in the directory src into dir, replacing any installed pack of the same name.
+// The pack is checked first, so a broken pack is never installed.
+func Install(src, dir string) (*Pack, error) {
+	p, err := Open(os.DirFS(src), src)
+	if err != nil {
+		return nil, err
+	}
+	if err := p.check(); err != nil {
+		return nil, err
+	}
+
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, err
+	}
+	// copy next to the destination first, so that a failed copy leaves the installed pack alone
+	tmp, err := os.MkdirTemp(dir, ".install-*")
+	if err != nil {
+		return nil, err
+	}
+	defer os.RemoveAll(tmp) // no-op once renamed
+
+	if err := os.CopyFS(tmp, os.DirFS(src)); err != nil {
+		return nil, err
+	}
+
+	// move the installed pack aside rather than deleting it, so that it can be put back if the switch fails
+	dest := filepath.Join(dir, p.Name)
+	old := filepath.Join(dir, ".old-"+p.Name)
+	if err := os.RemoveAll(old); err != nil {
+		return nil, err
+	}
+	if err := os.Rename(dest, old); err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return nil, err
+	}
+	if err := os.Rename(tmp, dest); err != nil {
+		if restoreErr := os.Rename(old, dest); restoreErr != nil && !errors.Is(restoreErr, fs.ErrNotExist) {
+			return nil, errors.Join(err, restoreErr)
+		}
+		return nil, err
+	}
+	os.RemoveAll(old) // a leftover starts with a dot, so Installed skips it
+	return Open(os.DirFS(dest), dest)
+}
@@ -0,0 +1,264 @@
+package pack_test
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"slices"
+	"strings"
+	"testing"
+	"testing/fstest"
+
+	"github.com/jharlan-hash/gospell/internal/api"
+	"github.com/jharlan-hash/gospell/internal/audio"
+	"github.com/jharlan-hash/gospell/internal/pack"
+)
+
+const manifest = `{
+  "format": 1,
+  "name": "birds",
+  "title": "Birds",
+  "version": "1.0.0",
+  "words": "words.csv",
+  "audio": {"toucan": "audio/toucan.wav"},
+  "tags": ["nature"]
+}`
+
+func birds() fstest.MapFS {
+	return fstest.MapFS{
+		"pack.json":        {Data: []byte(manifest)},
+		"words.csv":        {Data: []byte("toucan,a bird with a huge bill,,noun,tropical\nwren\n")},
+		"audio/toucan.wav": {Data: []byte("RIFF")},
+	}
+}
+
+func TestOpen(t *testing.T) {
+	p, err := pack.Open(birds(), "test")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	entries, err := p.Entries()
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []api.Entry{
+		{Word: "toucan", Definition: "a bird with a huge bill", PartOfSpeech: "noun", Tags: []string{"tropical", "nature"}},
+		{Word: "wren", Tags: []string{"nature"}},
+	}
+	if !reflect.DeepEqual(entries, want) {
+		t.Errorf("Entries() = %+v, want %+v", entries, want)
+	}
+
+	clips, err := p.Recordings()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := string(clips["toucan"]); got != "RIFF" || len(clips) != 1 {
+		t.Errorf("Recordings() = %q, want the clip of toucan", clips)
+	}
+}
+
+func TestManifest_Validate(t *testing.T) {
+	valid := pack.Manifest{Format: 1, Name: "birds", Words: "words.csv"}
+	tests := []struct {
+		name    string // description of this test case
+		modify  func(m *pack.Manifest)
+		wantErr string
+	}{
+		{"TestValid", func(m *pack.Manifest) {}, ""},
+		{"TestNoFormat", func(m *pack.Manifest) { m.Format = 0 }, "missing format"},
+		{"TestNewerFormat", func(m *pack.Manifest) { m.Format = pack.FormatVersion + 1 }, "newer gospell"},
+		{"TestNoName", func(m *pack.Manifest) { m.Name = "" }, "name"},
+		{"TestUpperCaseName", func(m *pack.Manifest) { m.Name = "Birds" }, "name"},
+		{"TestNameWithSlash", func(m *pack.Manifest) { m.Name = "../birds" }, "name"},
+		{"TestNoWords", func(m *pack.Manifest) { m.Words = "" }, "words"},
+		{"TestWordsOutsidePack", func(m *pack.Manifest) { m.Words = "../words.csv" }, "words"},
+		{"TestAudioOutsidePack", func(m *pack.Manifest) { m.Audio = map[string]string{"wren": "/tmp/wren.wav"} }, "audio"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := valid
+			tt.modify(&m)
+			err := m.Validate()
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Errorf("Validate() = %v, want nil", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("Validate() = %v, want an error about %s", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestEmbedded(t *testing.T) {
+	packs, err := pack.Embedded()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(packs) < 4 {
+		t.Errorf("got %d embedded packs, want at least 4", len(packs))
+	}
+	for _, p := range packs {
+		checkPack(t, p)
+	}
+}
+
+func TestOpen_Recordings(t *testing.T) {
+	p, err := pack.Open(os.DirFS(filepath.Join("testdata", "birds")), "testdata")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := checkPack(t, p); got != 1 {
+		t.Errorf("checked %d recordings, want the one of toucan", got)
+	}
+}
+
+// checkPack checks that every word of p is defined and used in a sentence, and that its recordings decode.
+// It returns the number of recordings.
+func checkPack(t *testing.T, p *pack.Pack) int {
+	t.Helper()
+	entries, err := p.Entries()
+	if err != nil {
+		t.Error(err)
+		return 0
+	}
+	for _, e := range entries {
+		if e.Definition == "" || e.Sentence == "" {
+			t.Errorf("pack %s: %q needs a definition and a sentence", p.Name, e.Word)
+		}
+	}
+
+	clips, err := p.Recordings()
+	if err != nil {
+		t.Error(err)
+		return 0
+	}
+	for word, clip := range clips {
+		if _, _, err := audio.Decode(clip); err != nil {
+			t.Errorf("pack %s: recording of %q: %v", p.Name, word, err)
+		}
+	}
+	return len(clips)
+}
+
+func TestInstall(t *testing.T) {
+	src, dir := t.TempDir(), t.TempDir()
+	if err := os.CopyFS(src, birds()); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := pack.Install(src, dir); err != nil {
+		t.Fatal(err)
+	}
+	// installing again replaces the pack
+	p, err := pack.Install(src, dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := filepath.Join(dir, "birds"); p.Source != want {
+		t.Errorf("installed to %s, want %s", p.Source, want)
+	}
+	if entries, _ := os.ReadDir(dir); len(entries) != 1 {
+		t.Errorf("reinstalling left %d entries in the packs dir, want only birds", len(entries))
+	}
+
+	found, err := pack.Find("birds", dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if found.Source != p.Source {
+		t.Errorf("Find() found the pack in %s, want %s", found.Source, p.Source)
+	}
+
+	installed, broken, err := pack.Installed(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(installed) != 1 || len(broken) != 0 {
+		t.Errorf("Installed() = %d packs, %d broken, want only birds", len(installed), len(broken))
+	}
+}
+
+func TestAll_SkipsBrokenInstalledPacks(t *testing.T) {
+	src, dir := t.TempDir(), t.TempDir()
+	if err := os.CopyFS(src, birds()); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := pack.Install(src, dir); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.MkdirAll(filepath.Join(dir, "mangled"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "mangled", pack.ManifestFile), []byte("{not json"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	packs, broken, err := pack.All(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(broken) != 1 || broken[0].Dir != filepath.Join(dir, "mangled") {
+		t.Errorf("All() broken = %v, want the mangled pack", broken)
+	}
+	var names []string
+	for _, p := range packs {
+		names = append(names, p.Name)
+	}
+	if !slices.Contains(names, "birds") || !slices.Contains(names, "sat-vocab") {
+		t.Errorf("All() = %v, want the installed and embedded packs despite the broken one", names)
+	}
+
+	if _, err := pack.Find("birds", dir); err != nil {
+		t.Errorf("Find() of a good pack next to a broken one: %v", err)
+	}
+	var brokenErr *pack.BrokenError
+	if _, err := pack.Find("mangled", dir); !errors.As(err, &brokenErr) {
+		t.Errorf("Find() of the broken pack = %v, want a BrokenError", err)
+	}
+}
+
+func TestInstall_Broken(t *testing.T) {
+	src, dir := t.TempDir(), t.TempDir()
+	broken := birds()
+	delete(broken, "audio/toucan.wav")
+	if err := os.CopyFS(src, broken); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := pack.Install(src, dir); err == nil {
+		t.Error("Install() of a pack with missing audio succeeded, want an error")
+	}
+	if entries, _ := os.ReadDir(dir); len(entries) != 0 {
+		t.Errorf("Install() left %d files behind", len(entries))
+	}
+}
+
+func TestFind_InstalledShadowsEmbedded(t *testing.T) {
+	src, dir := t.TempDir(), t.TempDir()
+	m := strings.Replace(manifest, `"birds"`, `"sat-vocab"`, 1)
+	fsys := birds()
+	fsys["pack.json"] = &fstest.MapFile{Data: []byte(m)}
+	if err := os.CopyFS(src, fsys); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := pack.Install(src, dir); err != nil {
+		t.Fatal(err)
+	}
+
+	p, err := pack.Find("sat-vocab", dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if p.Source == "embedded" {
+		t.Error("Find() returned the embedded pack, want the installed one")
+	}
+	if _, err := pack.Find("no-such-pack", dir); err == nil {
+		t.Error("Find() of a missing pack succeeded, want an error")
+	}
+}
@@ -0,0 +1,190 @@
+// Package review schedules missed words for spaced repetition.
+//
+// Every attempt at a word is recorded. A word enters the schedule the first time it is misspelled
+// and then follows the SM-2 algorithm: each correct review pushes it further out, by a factor that
+// shrinks for words that keep being missed, and a miss brings it back within minutes.
+// The schedule is kept in a JSON file so that it carries over between sessions.
+package review
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"math"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+)
+
+const (
+	// InitialEase is the interval factor of a newly scheduled word.
+	InitialEase = 2.5
+	// MinEase keeps intervals growing, if slowly, for the hardest words.
+	MinEase = 1.3
+	// RetryAfter is how soon a missed word comes up again.
+	RetryAfter = 10 * time.Minute
+	day        = 24 * time.Hour
+)
+
+// Attempt is one try at spelling a word.
+type Attempt struct {
+	Time    time.Time `json:"time"`
+	Answer  string    `json:"answer"`
+	Correct bool      `json:"correct"`
+}
+
+// Card is the review state of a word.
+type Card struct {
+	Word        string    `json:"word"`
+	Attempts    []Attempt `json:"attempts"`
+	Repetitions int       `json:"repetitions"`   // correct reviews in a row since the last miss
+	Interval    float64   `json:"interval_days"` // days between the last two reviews
+	Ease        float64   `json:"ease"`          // SM-2 easiness factor, see InitialEase
+	Due         time.Time `json:"due,omitzero"`  // zero until the word is first missed
+}
+
+// Scheduled reports whether the word is up for review at all, i.e. whether it was ever missed.
+func (c *Card) Scheduled() bool {
+	return !c.Due.IsZero()
+}
+
+// record adds an attempt and reschedules the card following SM-2,
+// grading a correct answer 4 ("correct after some thought") and a miss 1.
+func (c *Card) record(a Attempt) {
+	c.Attempts = append(c.Attempts, a)
+	if !a.Correct {
+		if c.Ease == 0 {
+			c.Ease = InitialEase
+		}
+		c.Repetitions = 0
+		c.Interval = 0
+		c.Ease = max(c.Ease-0.54, MinEase) // SM-2's ease update for grade 1
+		c.Due = a.Time.Add(RetryAfter)
+		return
+	}
+	if !c.Scheduled() {
+		return // never missed, nothing to review
+	}
+
+	c.Repetitions++
+	switch c.Repetitions {
+	case 1:
+		c.Interval = 1
+	case 2:
+		c.Interval = 6
+	default:
+		c.Interval = math.Round(c.Interval * c.Ease)
+	}
+	// grade 4 leaves the ease as it is
+	c.Due = a.Time.Add(time.Duration(c.Interval * float64(day)))
+}
+
+// Deck is the review state of every word attempted so far.
+// It is not safe for concurrent use.
+type Deck struct {
+	Path  string           // where Save writes the deck; empty keeps it in memory
+	Cards map[string]*Card // keyed by word
+}
+
+// DefaultPath returns where the deck is kept unless the config says otherwise,
+// e.g. $XDG_CONFIG_HOME/gospell/reviews.json on Linux.
+func DefaultPath() (string, error) {
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "gospell", "reviews.json"), nil
+}
+
+// Open reads the deck at path. A missing file is an empty deck.
+func Open(path string) (*Deck, error) {
+	d := &Deck{Path: path, Cards: make(map[string]*Card)}
+
+	b, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return d, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var cards []*Card
+	if err := json.Unmarshal(b, &cards); err != nil {
+		return nil, fmt.Errorf("parsing review file %s: %w", path, err)
+	}
+	for _, c := range cards {
+		d.Cards[c.Word] = c
+	}
+	return d, nil
+}
+
+// Save writes the deck to its Path, replacing the old file in one step
+// so a crash never leaves half a deck behind.
+func (d *Deck) Save() error {
+	if d.Path == "" {
+		return nil
+	}
+
+	cards := make([]*Card, 0, len(d.Cards))
+	for _, c := range d.Cards {
+		cards = append(cards, c)
+	}
+	sort.Slice(cards, func(i, j int) bool { return cards[i].Word < cards[j].Word })
+
+	b, err := json.MarshalIndent(cards, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(d.Path), 0o755); err != nil {
+		return err
+	}
+	tmp, err := os.CreateTemp(filepath.Dir(d.Path), ".tmp-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name()) // no-op once renamed
+
+	if _, err := tmp.Write(b); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), d.Path)
+}
+
+// Record adds an attempt at word made at now and reschedules it.
+func (d *Deck) Record(word, answer string, correct bool, now time.Time) {
+	c, ok := d.Cards[word]
+	if !ok {
+		c = &Card{Word: word}
+		d.Cards[word] = c
+	}
+	c.record(Attempt{Time: now, Answer: answer, Correct: correct})
+}
+
+// Due returns the words due for review at now, most overdue first.
+func (d *Deck) Due(now time.Time) []string {
+	var due []*Card
+	for _, c := range d.Cards {
+		if c.Scheduled() && !c.Due.After(now) {
+			due = append(due, c)
+		}
+	}
+	sort.Slice(due, func(i, j int) bool {
+		if !due[i].Due.Equal(due[j].Due) {
+			return due[i].Due.Before(due[j].Due)
+		}
+		return due[i].Word < due[j].Word
+	})
+
+	words := make([]string, len(due))
+	for i, c := range due {
+		words[i] = c.Word
+	}
+	return words
+}
@@ -0,0 +1,131 @@
+package review_test
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/jharlan-hash/gospell/internal/review"
+)
+
+var start = time.Date(2025, 3, 1, 9, 0, 0, 0, time.UTC)
+
+const day = 24 * time.Hour
+
+func TestDeck_Record(t *testing.T) {
+	tests := []struct {
+		name    string // description of this test case
+		answers []bool // correct or not, one day apart
+		wantDue time.Duration
+		wantInt float64
+	}{
+		{"TestNeverMissed", []bool{true, true}, 0, 0},
+		{"TestMissed", []bool{false}, review.RetryAfter, 0},
+		{"TestFirstReview", []bool{false, true}, day + day, 1},
+		{"TestSecondReview", []bool{false, true, true}, 2*day + 6*day, 6},
+		{"TestThirdReview", []bool{false, true, true, true}, 3*day + 12*day, 12}, // 6 days times an ease of 1.96
+		{"TestMissedAgain", []bool{false, true, true, false}, 3*day + review.RetryAfter, 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d := &review.Deck{Cards: make(map[string]*review.Card)}
+			for i, correct := range tt.answers {
+				d.Record("necessary", "answer", correct, start.Add(time.Duration(i)*day))
+			}
+
+			c := d.Cards["necessary"]
+			if len(c.Attempts) != len(tt.answers) {
+				t.Errorf("recorded %d attempts, want %d", len(c.Attempts), len(tt.answers))
+			}
+			if tt.wantDue == 0 {
+				if c.Scheduled() {
+					t.Errorf("Scheduled() = true with due %v, want false", c.Due)
+				}
+				return
+			}
+			if want := start.Add(tt.wantDue); !c.Due.Equal(want) {
+				t.Errorf("Due = %v, want %v", c.Due, want)
+			}
+			if c.Interval != tt.wantInt {
+				t.Errorf("Interval = %g, want %g", c.Interval, tt.wantInt)
+			}
+		})
+	}
+}
+
+func TestDeck_RecordCorrectFirstAttempt(t *testing.T) {
+	d := &review.Deck{Cards: make(map[string]*review.Card)}
+	d.Record("cat", "cat", true, start)
+
+	c, ok := d.Cards["cat"]
+	if !ok {
+		t.Fatal("no card for a word spelled right the first time, want its attempt recorded")
+	}
+	want := []review.Attempt{{Time: start, Answer: "cat", Correct: true}}
+	if !reflect.DeepEqual(c.Attempts, want) {
+		t.Errorf("Attempts = %+v, want %+v", c.Attempts, want)
+	}
+	if c.Scheduled() || len(d.Due(start.Add(365*day))) != 0 {
+		t.Error("a word that was never missed is scheduled for review")
+	}
+}
+
+func TestDeck_EaseFloor(t *testing.T) {
+	d := &review.Deck{Cards: make(map[string]*review.Card)}
+	for i := range 10 {
+		d.Record("rhythm", "rythm", false, start.Add(time.Duration(i)*time.Hour))
+	}
+	if ease := d.Cards["rhythm"].Ease; ease != review.MinEase {
+		t.Errorf("Ease after 10 misses = %g, want %g", ease, review.MinEase)
+	}
+}
+
+func TestDeck_Due(t *testing.T) {
+	d := &review.Deck{Cards: make(map[string]*review.Card)}
+	d.Record("b", "", false, start.Add(time.Minute))
+	d.Record("a", "", false, start)
+	d.Record("c", "", false, start.Add(time.Hour))
+	d.Record("d", "", true, start)
+
+	if got, want := d.Due(start.Add(30*time.Minute)), []string{"a", "b"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("Due() = %q, want %q", got, want)
+	}
+	if got := d.Due(start); len(got) != 0 {
+		t.Errorf("Due() right away = %q, want none", got)
+	}
+}
+
+func TestDeck_SaveOpen(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "gospell", "reviews.json")
+
+	d, err := review.Open(path)
+	if err != nil {
+		t.Fatalf("Open() of a missing file error = %v", err)
+	}
+	d.Record("necessary", "neccessary", false, start)
+	d.Record("necessary", "necessary", true, start.Add(day))
+	d.Record("cat", "cat", true, start)
+	if err := d.Save(); err != nil {
+		t.Fatalf("Save() error = %v", err)
+	}
+
+	got, err := review.Open(path)
+	if err != nil {
+		t.Fatalf("Open() error = %v", err)
+	}
+	if !reflect.DeepEqual(got.Cards, d.Cards) {
+		t.Errorf("Open() = %+v, want %+v", got.Cards, d.Cards)
+	}
+}
+
+func TestOpen_Corrupt(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "reviews.json")
+	if err := os.WriteFile(path, []byte("{not json"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := review.Open(path); err == nil {
+		t.Errorf("Open() of a corrupt file error = nil, want error")
+	}
+}
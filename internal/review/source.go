@@ -0,0 +1,69 @@
+package review
+
+import (
+	"math/rand"
+	"time"
+
+	"github.com/jharlan-hash/gospell/internal/api"
+)
+
+// DefaultRatio is the share of words that are due reviews, as long as any are due.
+const DefaultRatio = 0.3
+
+// Source is an api.WordSource that mixes the words due in a Deck into the words of another source.
+type Source struct {
+	Deck  *Deck
+	Words api.WordSource // new words, used whenever no review is due
+	Ratio float64        // share of words drawn from due reviews, 0 to 1
+	Now   func() time.Time
+
+	seed   int64
+	rng    *rand.Rand
+	served map[string]bool // due words handed out but not yet answered
+}
+
+// NewSource returns a Source that serves due reviews from deck at ratio, and otherwise words.
+func NewSource(deck *Deck, words api.WordSource, ratio float64) *Source {
+	s := &Source{Deck: deck, Words: words, Ratio: ratio, Now: time.Now, seed: int64(api.Rand64())}
+	s.Reset()
+	return s
+}
+
+// Next returns the most overdue review with probability Ratio, or else the next new word.
+// A review is handed out only once until it is answered, even if it is still due,
+// since the model draws words ahead of time.
+func (s *Source) Next() string {
+	if s.rng.Float64() < s.Ratio {
+		for _, word := range s.Deck.Due(s.Now()) {
+			if !s.served[word] {
+				s.served[word] = true
+				return word
+			}
+		}
+	}
+	return s.Words.Next()
+}
+
+// Record records an attempt at word in the Deck, which makes it eligible to be served again once due.
+func (s *Source) Record(word, answer string, correct bool) {
+	s.Deck.Record(word, answer, correct, s.Now())
+	delete(s.served, word)
+}
+
+// Len returns the number of new words; reviews are words the user has met before.
+func (s *Source) Len() int {
+	return s.Words.Len()
+}
+
+// Seed seeds the Source and, with a seed derived from seed, its new words.
+func (s *Source) Seed(seed int64) {
+	s.seed = seed
+	api.Seed(s.Words, rand.New(rand.NewSource(seed)).Int63())
+	s.Reset()
+}
+
+func (s *Source) Reset() {
+	s.rng = rand.New(rand.NewSource(s.seed))
+	s.served = make(map[string]bool)
+	s.Words.Reset()
+}
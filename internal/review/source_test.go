@@ -0,0 +1,70 @@
+package review_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/jharlan-hash/gospell/internal/api"
+	"github.com/jharlan-hash/gospell/internal/review"
+)
+
+// newSource returns a Source whose clock stands still at now, with "necessary" due for review.
+func newSource(ratio float64, now time.Time) *review.Source {
+	d := &review.Deck{Cards: make(map[string]*review.Card)}
+	d.Record("necessary", "neccessary", false, now.Add(-time.Hour))
+
+	s := review.NewSource(d, api.NewList([]string{"cat"}), ratio)
+	s.Now = func() time.Time { return now }
+	return s
+}
+
+func TestSource_Next(t *testing.T) {
+	tests := []struct {
+		name  string // description of this test case
+		ratio float64
+		want  []string
+	}{
+		{"TestNoReviews", 0, []string{"cat", "cat", "cat"}},
+		{"TestReviewServedOnce", 1, []string{"necessary", "cat", "cat"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := newSource(tt.ratio, start)
+			for i, want := range tt.want {
+				if got := s.Next(); got != want {
+					t.Errorf("Next() #%d = %q, want %q", i+1, got, want)
+				}
+			}
+		})
+	}
+}
+
+func TestSource_Record(t *testing.T) {
+	s := newSource(1, start)
+	if got := s.Next(); got != "necessary" {
+		t.Fatalf("Next() = %q, want the due review", got)
+	}
+
+	// missed again, so it is due in RetryAfter and comes back then
+	s.Record("necessary", "necesary", false)
+	if got := s.Next(); got != "cat" {
+		t.Errorf("Next() before the retry = %q, want cat", got)
+	}
+	s.Now = func() time.Time { return start.Add(review.RetryAfter) }
+	if got := s.Next(); got != "necessary" {
+		t.Errorf("Next() at the retry = %q, want necessary", got)
+	}
+}
+
+func TestSource_Seed(t *testing.T) {
+	a, b := newSource(0.5, start), newSource(0.5, start)
+	a.Words, b.Words = api.NewList([]string{"cat", "dog", "owl"}), api.NewList([]string{"cat", "dog", "owl"})
+	a.Seed(42)
+	b.Seed(42)
+
+	for i := range 20 {
+		if x, y := a.Next(), b.Next(); x != y {
+			t.Fatalf("Next() #%d = %q and %q with the same seed, want the same word", i+1, x, y)
+		}
+	}
+}
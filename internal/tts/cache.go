@@ -0,0 +1,62 @@
+package tts
+
+import (
+	"os"
+	"path/filepath"
+)
+
+// Cache is a content-addressed on-disk store of synthesized audio.
+// Clips are keyed by everything that affects the audio: the text and the full Voice.
+type Cache struct {
+	Dir string
+}
+
+// DefaultCacheDir returns the audio cache directory under the user's cache dir,
+// e.g. $XDG_CACHE_HOME/gospell/audio on Linux.
+func DefaultCacheDir() (string, error) {
+	dir, err := os.UserCacheDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "gospell", "audio"), nil
+}
+
+// path returns where the clip for a request is stored.
+// Clips are sharded by the first two characters of the key to keep directories small.
+func (c *Cache) path(req Request) string {
+	key := req.Key()
+	return filepath.Join(c.Dir, key[:2], key)
+}
+
+// Get returns the cached audio for a request, if there is any.
+func (c *Cache) Get(req Request) ([]byte, bool) {
+	audio, err := os.ReadFile(c.path(req))
+	if err != nil || len(audio) == 0 {
+		return nil, false
+	}
+	return audio, true
+}
+
+// Put stores the audio for a request.
+// The clip is written to a temporary file first so readers never see a partial clip.
+func (c *Cache) Put(req Request, audio []byte) error {
+	path := c.path(req)
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(path), ".tmp-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name()) // no-op once renamed
+
+	if _, err := tmp.Write(audio); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), path)
+}
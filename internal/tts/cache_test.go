@@ -0,0 +1,62 @@
+package tts_test
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/jharlan-hash/gospell/internal/tts"
+)
+
+func TestCache_PutGet(t *testing.T) {
+	slow := tts.DefaultVoice
+	slow.SpeakingRate = 0.5
+
+	tests := []struct {
+		name   string // description of this test case
+		put    tts.Request
+		get    tts.Request
+		wantOk bool
+	}{
+		{"TestSameRequest", tts.Request{Text: "example", Voice: tts.DefaultVoice}, tts.Request{Text: "example", Voice: tts.DefaultVoice}, true},
+		{"TestDifferentWord", tts.Request{Text: "example", Voice: tts.DefaultVoice}, tts.Request{Text: "sample", Voice: tts.DefaultVoice}, false},
+		{"TestDifferentSpeakingRate", tts.Request{Text: "example", Voice: tts.DefaultVoice}, tts.Request{Text: "example", Voice: slow}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &tts.Cache{Dir: t.TempDir()}
+			audio := []byte("RIFF fake audio")
+
+			if err := c.Put(tt.put, audio); err != nil {
+				t.Fatalf("Put() error = %v", err)
+			}
+
+			got, ok := c.Get(tt.get)
+			if ok != tt.wantOk {
+				t.Fatalf("Get() ok = %v, want %v", ok, tt.wantOk)
+			}
+			if ok && !bytes.Equal(got, audio) {
+				t.Errorf("Get() = %q, want %q", got, audio)
+			}
+		})
+	}
+}
+
+func TestCache_PutLeavesNoTempFiles(t *testing.T) {
+	c := &tts.Cache{Dir: t.TempDir()}
+	req := tts.Request{Text: "example", Voice: tts.DefaultVoice}
+
+	if err := c.Put(req, []byte("audio")); err != nil {
+		t.Fatalf("Put() error = %v", err)
+	}
+
+	key := req.Key()
+	entries, err := os.ReadDir(filepath.Join(c.Dir, key[:2]))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) != 1 || entries[0].Name() != key {
+		t.Errorf("cache dir holds %v, want only %s", entries, key)
+	}
+}
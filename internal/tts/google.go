@@ -0,0 +1,109 @@
+package tts
+
+import (
+	"context"
+
+	texttospeech "cloud.google.com/go/texttospeech/apiv1"
+	"cloud.google.com/go/texttospeech/apiv1/texttospeechpb"
+	"google.golang.org/api/option"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+)
+
+// Google is a Synthesizer backed by the Google Cloud Text-to-Speech API.
+type Google struct {
+	Client *texttospeech.Client
+}
+
+// NewGoogle creates a Google synthesizer authenticated with the given credentials file.
+// Extra options, such as option.WithEndpoint, are passed on to the API client.
+func NewGoogle(ctx context.Context, credentialPath string, opts ...option.ClientOption) (*Google, error) {
+	opts = append([]option.ClientOption{option.WithCredentialsFile(credentialPath)}, opts...)
+	client, err := texttospeech.NewClient(ctx, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return &Google{Client: client}, nil
+}
+
+// NewGoogleAt creates a Google synthesizer that talks to a TextToSpeech server at endpoint,
+// e.g. "localhost:8080", over plain gRPC and without credentials.
+// It is meant for local stand-ins of the API, such as the one in the tests.
+func NewGoogleAt(ctx context.Context, endpoint string) (*Google, error) {
+	client, err := texttospeech.NewClient(ctx,
+		option.WithEndpoint(endpoint),
+		option.WithoutAuthentication(),
+		option.WithGRPCDialOption(grpc.WithTransportCredentials(insecure.NewCredentials())),
+	)
+	if err != nil {
+		return nil, err
+	}
+	return &Google{Client: client}, nil
+}
+
+// SynthesizeSpeech uses the Google Cloud Text-to-Speech API to synthesize speech from the given request.
+// It creates an API request with the text, voice parameters, and audio configuration.
+// It then calls the API and returns the synthesized audio content.
+func (g *Google) SynthesizeSpeech(ctx context.Context, r Request) ([]byte, error) {
+	// set up request
+	input := &texttospeechpb.SynthesisInput{
+		InputSource: &texttospeechpb.SynthesisInput_Text{Text: r.Text},
+	}
+	if r.SSML {
+		input.InputSource = &texttospeechpb.SynthesisInput_Ssml{Ssml: r.Text}
+	}
+
+	req := texttospeechpb.SynthesizeSpeechRequest{
+		Input: input,
+		// configure voice
+		Voice: &texttospeechpb.VoiceSelectionParams{
+			LanguageCode: r.Voice.LanguageCode,
+			Name:         r.Voice.Name,
+			SsmlGender:   texttospeechpb.SsmlVoiceGender(texttospeechpb.SsmlVoiceGender_value[r.Voice.Gender]),
+		},
+		// Configure the audio output
+		AudioConfig: &texttospeechpb.AudioConfig{
+			AudioEncoding: texttospeechpb.AudioEncoding(texttospeechpb.AudioEncoding_value[string(r.Voice.Encoding)]),
+			SpeakingRate:  r.Voice.SpeakingRate,
+			Pitch:         r.Voice.Pitch,
+			VolumeGainDb:  r.Voice.VolumeGainDb,
+		},
+	}
+
+	// Call the API
+	resp, err := g.Client.SynthesizeSpeech(ctx, &req)
+	if err != nil && ctx.Err() != nil {
+		return nil, ctx.Err() // the gRPC status error doesn't wrap it, so callers couldn't tell a cancel apart
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return resp.AudioContent, nil
+}
+
+// ListVoices returns the voices the API offers for a language code, e.g. "en-US" or just "en".
+// An empty language code lists every voice.
+func (g *Google) ListVoices(ctx context.Context, languageCode string) ([]Voice, error) {
+	resp, err := g.Client.ListVoices(ctx, &texttospeechpb.ListVoicesRequest{LanguageCode: languageCode})
+	if err != nil {
+		return nil, err
+	}
+
+	voices := make([]Voice, 0, len(resp.Voices))
+	for _, v := range resp.Voices {
+		for _, code := range v.LanguageCodes {
+			voices = append(voices, Voice{
+				Name:         v.Name,
+				LanguageCode: code,
+				Gender:       v.SsmlGender.String(),
+			})
+		}
+	}
+	return voices, nil
+}
+
+// Close closes the underlying API client.
+func (g *Google) Close() error {
+	return g.Client.Close()
+}
@@ -0,0 +1,277 @@
+package tts_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"errors"
+	"math"
+	"net"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"cloud.google.com/go/texttospeech/apiv1/texttospeechpb"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/jharlan-hash/gospell/internal/audio"
+	"github.com/jharlan-hash/gospell/internal/tts"
+)
+
+// fakeServer is a local stand-in for the Google Cloud TextToSpeech API.
+// It speaks every text as a short WAV tone, and treats some texts specially:
+// "fail" is rejected, and "hang" blocks until the caller gives up.
+type fakeServer struct {
+	texttospeechpb.UnimplementedTextToSpeechServer
+
+	mu    sync.Mutex
+	calls map[string]int
+}
+
+func (s *fakeServer) SynthesizeSpeech(ctx context.Context, req *texttospeechpb.SynthesizeSpeechRequest) (*texttospeechpb.SynthesizeSpeechResponse, error) {
+	text := req.Input.GetText() + req.Input.GetSsml()
+
+	s.mu.Lock()
+	s.calls[text]++
+	s.mu.Unlock()
+
+	switch {
+	case text == "fail":
+		// InvalidArgument isn't retried by the client, unlike Unavailable
+		return nil, status.Error(codes.InvalidArgument, "cannot say that")
+	case text == "hang":
+		<-ctx.Done()
+		return nil, ctx.Err()
+	case req.AudioConfig.AudioEncoding != texttospeechpb.AudioEncoding_LINEAR16:
+		return nil, status.Error(codes.InvalidArgument, "the fake server only speaks LINEAR16")
+	}
+
+	// a different pitch for every word length, so clips differ
+	tone := wavTone(220*float64(len(text)), 24000, 2400)
+	return &texttospeechpb.SynthesizeSpeechResponse{AudioContent: tone}, nil
+}
+
+func (s *fakeServer) ListVoices(ctx context.Context, req *texttospeechpb.ListVoicesRequest) (*texttospeechpb.ListVoicesResponse, error) {
+	voices := []*texttospeechpb.Voice{
+		{Name: "en-US-Fake-A", LanguageCodes: []string{"en-US"}, SsmlGender: texttospeechpb.SsmlVoiceGender_FEMALE},
+		{Name: "en-GB-Fake-B", LanguageCodes: []string{"en-GB"}, SsmlGender: texttospeechpb.SsmlVoiceGender_MALE},
+	}
+
+	resp := &texttospeechpb.ListVoicesResponse{}
+	for _, v := range voices {
+		if strings.HasPrefix(v.LanguageCodes[0], req.LanguageCode) {
+			resp.Voices = append(resp.Voices, v)
+		}
+	}
+	return resp, nil
+}
+
+func (s *fakeServer) count(text string) int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.calls[text]
+}
+
+// startFakeServer serves a fakeServer on a local port for the duration of the test
+// and returns a Google synthesizer connected to it.
+func startFakeServer(t *testing.T) (*fakeServer, *tts.Google) {
+	t.Helper()
+
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	fake := &fakeServer{calls: make(map[string]int)}
+	srv := grpc.NewServer()
+	texttospeechpb.RegisterTextToSpeechServer(srv, fake)
+	go srv.Serve(lis)
+	t.Cleanup(srv.Stop)
+
+	google, err := tts.NewGoogleAt(context.Background(), lis.Addr().String())
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { google.Close() })
+
+	return fake, google
+}
+
+// wavTone builds a mono 16-bit PCM WAV clip of a sine tone.
+func wavTone(freq float64, sampleRate, samples int) []byte {
+	var buf bytes.Buffer
+	dataSize := samples * 2
+	buf.WriteString("RIFF")
+	binary.Write(&buf, binary.LittleEndian, uint32(36+dataSize))
+	buf.WriteString("WAVEfmt ")
+	binary.Write(&buf, binary.LittleEndian, uint32(16))           // fmt chunk size
+	binary.Write(&buf, binary.LittleEndian, uint16(1))            // PCM
+	binary.Write(&buf, binary.LittleEndian, uint16(1))            // mono
+	binary.Write(&buf, binary.LittleEndian, uint32(sampleRate))   // sample rate
+	binary.Write(&buf, binary.LittleEndian, uint32(sampleRate*2)) // byte rate
+	binary.Write(&buf, binary.LittleEndian, uint16(2))            // block align
+	binary.Write(&buf, binary.LittleEndian, uint16(16))           // bits per sample
+	buf.WriteString("data")
+	binary.Write(&buf, binary.LittleEndian, uint32(dataSize))
+	for i := range samples {
+		v := math.Sin(2 * math.Pi * freq * float64(i) / float64(sampleRate))
+		binary.Write(&buf, binary.LittleEndian, int16(v*math.MaxInt16/2))
+	}
+	return buf.Bytes()
+}
+
+// clipPlayer records the clips it is asked to play.
+type clipPlayer struct {
+	audio.Discard
+
+	mu    sync.Mutex
+	clips [][]byte
+}
+
+func (p *clipPlayer) Play(clip []byte, speed float64) error {
+	if _, _, err := audio.Decode(clip); err != nil {
+		return err
+	}
+	p.mu.Lock()
+	p.clips = append(p.clips, clip)
+	p.mu.Unlock()
+	return nil
+}
+
+func TestGoogle_SynthesizeSpeech(t *testing.T) {
+	_, google := startFakeServer(t)
+
+	clip, err := google.SynthesizeSpeech(context.Background(), tts.Request{Text: "example", Voice: tts.DefaultVoice})
+	if err != nil {
+		t.Fatalf("SynthesizeSpeech() error = %v", err)
+	}
+
+	s, format, err := audio.Decode(clip)
+	if err != nil {
+		t.Fatalf("Decode() of synthesized clip error = %v", err)
+	}
+	if format.SampleRate != 24000 || s.Len() != 2400 {
+		t.Errorf("synthesized clip is %d samples at %d Hz, want 2400 at 24000 Hz", s.Len(), format.SampleRate)
+	}
+}
+
+func TestGoogle_ListVoices(t *testing.T) {
+	_, google := startFakeServer(t)
+
+	voices, err := google.ListVoices(context.Background(), "en-GB")
+	if err != nil {
+		t.Fatalf("ListVoices() error = %v", err)
+	}
+
+	want := []tts.Voice{{Name: "en-GB-Fake-B", LanguageCode: "en-GB", Gender: "MALE"}}
+	if len(voices) != 1 || voices[0] != want[0] {
+		t.Errorf("ListVoices() = %+v, want %+v", voices, want)
+	}
+}
+
+func TestTTS_SayWordCachesAcrossSessions(t *testing.T) {
+	fake, google := startFakeServer(t)
+	cache := &tts.Cache{Dir: t.TempDir()}
+
+	for session := range 2 {
+		player := &clipPlayer{}
+		speech := &tts.TTS{Synthesizer: google, Player: player, Cache: cache, Voice: tts.DefaultVoice, Ctx: context.Background()}
+
+		if err := speech.SayWord("example"); err != nil {
+			t.Fatalf("session %d: SayWord() error = %v", session, err)
+		}
+		if len(player.clips) != 1 {
+			t.Errorf("session %d: played %d clips, want 1", session, len(player.clips))
+		}
+	}
+
+	if got := fake.count("example"); got != 1 {
+		t.Errorf("server synthesized %d times, want 1", got)
+	}
+}
+
+func TestTTS_SayWordServerError(t *testing.T) {
+	fake, google := startFakeServer(t)
+	cache := &tts.Cache{Dir: t.TempDir()}
+	player := &clipPlayer{}
+	speech := &tts.TTS{Synthesizer: google, Player: player, Cache: cache, Voice: tts.DefaultVoice, Ctx: context.Background()}
+
+	for range 2 {
+		err := speech.SayWord("fail")
+		if err == nil || !strings.Contains(err.Error(), "cannot say that") {
+			t.Errorf("SayWord() error = %v, want the server's error", err)
+		}
+	}
+
+	// failures are neither played nor cached, so every attempt reaches the server
+	if len(player.clips) != 0 {
+		t.Errorf("played %d clips, want 0", len(player.clips))
+	}
+	if got := fake.count("fail"); got != 2 {
+		t.Errorf("server called %d times, want 2", got)
+	}
+}
+
+func TestTTS_SayWordUnsupportedEncoding(t *testing.T) {
+	_, google := startFakeServer(t)
+	voice := tts.DefaultVoice
+	voice.Encoding = tts.OggOpus
+	speech := &tts.TTS{Synthesizer: google, Voice: voice, Ctx: context.Background()}
+
+	if err := speech.SayWord("example"); err == nil {
+		t.Errorf("SayWord() = nil, want error")
+	}
+}
+
+func TestTTS_SayWordCancel(t *testing.T) {
+	_, google := startFakeServer(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	speech := &tts.TTS{Synthesizer: google, Voice: tts.DefaultVoice, Ctx: ctx}
+
+	errc := make(chan error)
+	go func() { errc <- speech.SayWord("hang") }()
+
+	time.Sleep(50 * time.Millisecond) // let the request reach the server
+	cancel()
+
+	select {
+	case err := <-errc:
+		if !errors.Is(err, context.Canceled) {
+			t.Errorf("SayWord() after cancel = %v, want %v", err, context.Canceled)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("SayWord() did not return after cancel")
+	}
+}
+
+func TestTTS_SayWordConcurrent(t *testing.T) {
+	fake, google := startFakeServer(t)
+	player := &clipPlayer{}
+	speech := &tts.TTS{Synthesizer: google, Player: player, Voice: tts.DefaultVoice, Ctx: context.Background()}
+
+	words := []string{"a", "bb", "ccc"}
+	var wg sync.WaitGroup
+	for i := range 30 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if err := speech.SayWord(words[i%len(words)]); err != nil {
+				t.Errorf("SayWord() error = %v", err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	// calls that were overtaken by a later one play nothing
+	if len(player.clips) < 1 || len(player.clips) > 30 {
+		t.Errorf("played %d clips, want between 1 and 30", len(player.clips))
+	}
+	for _, word := range words {
+		if got := fake.count(word); got < 1 || got > 10 {
+			t.Errorf("%q synthesized %d times, want between 1 and 10", word, got)
+		}
+	}
+}
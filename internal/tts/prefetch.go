@@ -0,0 +1,158 @@
+package tts
+
+import (
+	"fmt"
+	"sync"
+)
+
+// DefaultConcurrency is how many prefetches may run at once when TTS.Concurrency is unset.
+const DefaultConcurrency = 2
+
+// maxPending bounds how many prefetched words are kept waiting for SayWord.
+// Words that never come up, e.g. because they were skipped, are evicted oldest first.
+const maxPending = 32
+
+// prefetch is the pending or finished result of a synthesis, started by Prefetch or SayWord.
+type prefetch struct {
+	done       chan struct{} // closed once audio and err are set
+	voice      Voice         // the voice the word is spoken in
+	background bool          // started by Prefetch
+	audio      []byte
+	err        error
+}
+
+// prefetchState holds the in-flight and finished prefetches of a TTS.
+type prefetchState struct {
+	mu      sync.Mutex
+	sem     chan struct{}
+	pending map[string]*prefetch // keyed by word
+	order   []string             // words in pending, oldest first
+}
+
+// Prefetch starts synthesizing the given words in the background, so that a later
+// SayWord for any of them can play without waiting on the backend.
+// At most Concurrency syntheses run at once, and all of them stop when Ctx is cancelled.
+// The requests are built in the background too, since Describe may look words up over the network.
+func (t *TTS) Prefetch(words ...string) {
+	t.prefetch.mu.Lock()
+	defer t.prefetch.mu.Unlock()
+
+	for _, word := range words {
+		if _, ok := t.Recordings[word]; ok {
+			continue // nothing to synthesize
+		}
+		if p, ok := t.prefetch.pending[word]; ok && p.voice == t.Voice {
+			continue // already queued
+		}
+		t.start(word, t.Voice, true)
+	}
+}
+
+// start synthesizes a word in a voice on a new goroutine and queues the result.
+// Background syntheses wait for one of the Concurrency slots; those SayWord waits on don't.
+// The caller holds prefetch.mu.
+func (t *TTS) start(word string, voice Voice, background bool) *prefetch {
+	if t.prefetch.sem == nil {
+		n := t.Concurrency
+		if n <= 0 {
+			n = DefaultConcurrency
+		}
+		t.prefetch.sem = make(chan struct{}, n)
+		t.prefetch.pending = make(map[string]*prefetch)
+	}
+
+	p := &prefetch{done: make(chan struct{}), voice: voice, background: background}
+	t.prefetch.add(word, p)
+
+	go func() {
+		defer close(p.done)
+
+		if background {
+			select {
+			case t.prefetch.sem <- struct{}{}:
+				defer func() { <-t.prefetch.sem }()
+			case <-t.Ctx.Done():
+				p.err = t.Ctx.Err()
+				return
+			}
+		}
+
+		req, err := t.requestWith(word, p.voice)
+		if err != nil {
+			p.err = fmt.Errorf("error building speech request: %w", err)
+			return
+		}
+		p.audio, err = t.synthesize(req)
+		if err != nil {
+			p.err = fmt.Errorf("error synthesizing speech: %w", err)
+		}
+	}()
+	return p
+}
+
+// add queues the prefetch of a word, replacing any earlier one for it,
+// and evicts the oldest prefetches beyond maxPending. The caller holds mu.
+func (s *prefetchState) add(word string, p *prefetch) {
+	if _, ok := s.pending[word]; ok {
+		s.remove(word)
+	}
+	s.pending[word] = p
+	s.order = append(s.order, word)
+
+	for len(s.order) > maxPending {
+		// an evicted synthesis still runs to completion and fills the on-disk cache
+		delete(s.pending, s.order[0])
+		s.order = s.order[1:]
+	}
+}
+
+// remove forgets the prefetch of a word. The caller holds mu.
+func (s *prefetchState) remove(word string) {
+	delete(s.pending, word)
+	for i, w := range s.order {
+		if w == word {
+			s.order = append(s.order[:i], s.order[i+1:]...)
+			break
+		}
+	}
+}
+
+// fetch returns the audio for a word in a voice, waiting for its prefetch or synthesizing it now.
+// Concurrent calls for the same word share one synthesis. A failed prefetch is retried once.
+func (t *TTS) fetch(word string, voice Voice) ([]byte, error) {
+	p := t.pendingFor(word, voice)
+	audio, err := t.wait(word, p)
+	if err != nil && p.background && t.Ctx.Err() == nil {
+		// the prefetch failed and is worth retrying, e.g. after a network hiccup
+		audio, err = t.wait(word, t.pendingFor(word, voice))
+	}
+	return audio, err
+}
+
+// pendingFor returns the queued synthesis of a word in a voice, starting one if there is none.
+func (t *TTS) pendingFor(word string, voice Voice) *prefetch {
+	t.prefetch.mu.Lock()
+	defer t.prefetch.mu.Unlock()
+
+	if p, ok := t.prefetch.pending[word]; ok && p.voice == voice {
+		return p
+	}
+	return t.start(word, voice, false)
+}
+
+// wait waits for a synthesis of a word and forgets it once it is done;
+// SayWord remembers the word it played, and the on-disk cache holds the rest.
+func (t *TTS) wait(word string, p *prefetch) ([]byte, error) {
+	select {
+	case <-p.done:
+	case <-t.Ctx.Done():
+		return nil, t.Ctx.Err()
+	}
+
+	t.prefetch.mu.Lock()
+	if t.prefetch.pending[word] == p {
+		t.prefetch.remove(word)
+	}
+	t.prefetch.mu.Unlock()
+	return p.audio, p.err
+}
@@ -0,0 +1,246 @@
+package tts_test
+
+import (
+	"context"
+	"slices"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/jharlan-hash/gospell/internal/audio"
+	"github.com/jharlan-hash/gospell/internal/tts"
+)
+
+// countingSynthesizer records how often, and how concurrently, it is called.
+// It produces no audio so SayWord doesn't need a sound card.
+type countingSynthesizer struct {
+	mu        sync.Mutex
+	calls     map[string]int
+	active    int
+	maxActive int
+	release   chan struct{} // if set, each call blocks until it is closed or the context ends
+}
+
+func (s *countingSynthesizer) SynthesizeSpeech(ctx context.Context, req tts.Request) ([]byte, error) {
+	s.mu.Lock()
+	if s.calls == nil {
+		s.calls = make(map[string]int)
+	}
+	s.calls[req.Text]++
+	s.active++
+	s.maxActive = max(s.maxActive, s.active)
+	s.mu.Unlock()
+
+	defer func() {
+		s.mu.Lock()
+		s.active--
+		s.mu.Unlock()
+	}()
+
+	if s.release != nil {
+		select {
+		case <-s.release:
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	}
+	return nil, nil
+}
+
+func (s *countingSynthesizer) count(word string) int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.calls[word]
+}
+
+func TestTTS_PrefetchAvoidsSecondSynthesis(t *testing.T) {
+	synth := &countingSynthesizer{}
+	tt := &tts.TTS{Synthesizer: synth, Voice: tts.DefaultVoice, Ctx: context.Background()}
+
+	tt.Prefetch("example", "sample")
+	for _, word := range []string{"example", "sample"} {
+		if err := tt.SayWord(word); err != nil {
+			t.Fatalf("SayWord(%q) error = %v", word, err)
+		}
+		if got := synth.count(word); got != 1 {
+			t.Errorf("%q synthesized %d times, want 1", word, got)
+		}
+	}
+}
+
+func TestTTS_PrefetchConcurrencyIsBounded(t *testing.T) {
+	synth := &countingSynthesizer{release: make(chan struct{})}
+	tt := &tts.TTS{Synthesizer: synth, Voice: tts.DefaultVoice, Concurrency: 2, Ctx: context.Background()}
+
+	words := []string{"a", "b", "c", "d", "e"}
+	tt.Prefetch(words...)
+	close(synth.release)
+
+	for _, word := range words {
+		if err := tt.SayWord(word); err != nil {
+			t.Fatalf("SayWord(%q) error = %v", word, err)
+		}
+	}
+	synth.mu.Lock()
+	defer synth.mu.Unlock()
+	if synth.maxActive > 2 {
+		t.Errorf("%d concurrent syntheses, want at most 2", synth.maxActive)
+	}
+}
+
+func TestTTS_PrefetchStopsOnCancel(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	synth := &countingSynthesizer{release: make(chan struct{})} // never released
+	tt := &tts.TTS{Synthesizer: synth, Voice: tts.DefaultVoice, Ctx: ctx}
+
+	tt.Prefetch("example")
+	cancel()
+
+	if err := tt.SayWord("example"); err == nil {
+		t.Errorf("SayWord() after cancel = nil, want error")
+	}
+}
+
+// rateSynthesizer records the speaking rates it was asked to synthesize at.
+type rateSynthesizer struct {
+	mu    sync.Mutex
+	rates []float64
+}
+
+func (s *rateSynthesizer) SynthesizeSpeech(ctx context.Context, req tts.Request) ([]byte, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.rates = append(s.rates, req.Voice.SpeakingRate)
+	return nil, nil
+}
+
+func TestTTS_PrefetchInOldVoiceIsNotPlayed(t *testing.T) {
+	synth := &rateSynthesizer{}
+	tt := &tts.TTS{Synthesizer: synth, Voice: tts.DefaultVoice, Ctx: context.Background()}
+
+	tt.Prefetch("example")
+	tt.Voice.SpeakingRate = 2
+	if err := tt.SayWord("example"); err != nil {
+		t.Fatalf("SayWord() error = %v", err)
+	}
+
+	synth.mu.Lock()
+	defer synth.mu.Unlock()
+	if !slices.Contains(synth.rates, 2) {
+		t.Errorf("synthesized at rates %v, want the word synthesized again at 2", synth.rates)
+	}
+}
+
+// echoSynthesizer "speaks" a word as its own text, so a player can tell words apart without decoding.
+// Words in slow block until slow is closed or the context ends.
+type echoSynthesizer struct {
+	countingSynthesizer
+	slow map[string]bool
+	gate chan struct{}
+}
+
+func (s *echoSynthesizer) SynthesizeSpeech(ctx context.Context, req tts.Request) ([]byte, error) {
+	s.countingSynthesizer.SynthesizeSpeech(ctx, req)
+	if s.slow[req.Text] {
+		select {
+		case <-s.gate:
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	}
+	return []byte(req.Text), nil
+}
+
+// wordPlayer records the words it is asked to play, as spoken by echoSynthesizer.
+type wordPlayer struct {
+	audio.Discard
+
+	mu    sync.Mutex
+	words []string
+}
+
+func (p *wordPlayer) Play(clip []byte, speed float64) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.words = append(p.words, string(clip))
+	return nil
+}
+
+func (p *wordPlayer) played() []string {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return slices.Clone(p.words)
+}
+
+// waitForCall waits until synth has been asked to synthesize word.
+func waitForCall(t *testing.T, synth *echoSynthesizer, word string) {
+	t.Helper()
+	for deadline := time.Now().Add(5 * time.Second); synth.count(word) == 0; time.Sleep(time.Millisecond) {
+		if time.Now().After(deadline) {
+			t.Fatalf("%q was never synthesized", word)
+		}
+	}
+}
+
+func TestTTS_SayWordInterruptsSlowSynthesis(t *testing.T) {
+	synth := &echoSynthesizer{slow: map[string]bool{"slow": true}, gate: make(chan struct{})}
+	player := &wordPlayer{}
+	tt := &tts.TTS{Synthesizer: synth, Player: player, Voice: tts.DefaultVoice, Ctx: context.Background()}
+
+	errc := make(chan error)
+	go func() { errc <- tt.SayWord("slow") }()
+	waitForCall(t, synth, "slow")
+
+	// a later word doesn't wait for the slow one
+	done := make(chan error)
+	go func() { done <- tt.SayWord("fast") }()
+	select {
+	case err := <-done:
+		if err != nil {
+			t.Fatalf("SayWord(fast) error = %v", err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("SayWord(fast) waited for the slow synthesis")
+	}
+
+	// and the slow one isn't played over it once it arrives
+	close(synth.gate)
+	if err := <-errc; err != nil {
+		t.Fatalf("SayWord(slow) error = %v", err)
+	}
+	if err := tt.PlayAudio(); err != nil {
+		t.Fatalf("PlayAudio() error = %v", err)
+	}
+	if got, want := player.played(), []string{"fast", "fast"}; !slices.Equal(got, want) {
+		t.Errorf("played %q, want %q", got, want)
+	}
+}
+
+func TestTTS_SayWordSharesSynthesis(t *testing.T) {
+	synth := &echoSynthesizer{slow: map[string]bool{"slow": true}, gate: make(chan struct{})}
+	player := &wordPlayer{}
+	tt := &tts.TTS{Synthesizer: synth, Player: player, Voice: tts.DefaultVoice, Ctx: context.Background()}
+
+	// pressing replay while the word is still being synthesized
+	var wg sync.WaitGroup
+	for range 3 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if err := tt.SayWord("slow"); err != nil {
+				t.Errorf("SayWord() error = %v", err)
+			}
+		}()
+	}
+	waitForCall(t, synth, "slow")
+	time.Sleep(20 * time.Millisecond) // let the other calls catch up
+	close(synth.gate)
+	wg.Wait()
+
+	if got := synth.count("slow"); got != 1 {
+		t.Errorf("synthesized %d times, want 1", got)
+	}
+	if got, want := player.played(), []string{"slow"}; !slices.Equal(got, want) {
+		t.Errorf("played %q, want %q", got, want)
+	}
+}
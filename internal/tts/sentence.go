@@ -0,0 +1,15 @@
+package tts
+
+import "fmt"
+
+// SaySentence speaks a sentence as plain text in the configured voice, e.g. an example sentence using a word.
+// Like words, sentences are cached, but they don't replace the word that PlayAudio repeats.
+func (t *TTS) SaySentence(sentence string) error {
+	turn, _ := t.begin()
+
+	audio, err := t.synthesize(Request{Text: sentence, Voice: t.Voice})
+	if err != nil {
+		return fmt.Errorf("error synthesizing sentence: %w", err)
+	}
+	return t.playTurn(turn, "", audio, 1)
+}
@@ -0,0 +1,12 @@
+package tts
+
+import "context"
+
+// Silent is a Synthesizer that produces no audio.
+// It lets gospell run without Google Cloud credentials, e.g. in CI or offline.
+type Silent struct{}
+
+// SynthesizeSpeech returns empty audio for any request.
+func (Silent) SynthesizeSpeech(ctx context.Context, req Request) ([]byte, error) {
+	return nil, nil
+}
@@ -0,0 +1,50 @@
+package tts
+
+import "fmt"
+
+const (
+	// MinSpeakingRate is the slowest speaking rate the API accepts.
+	MinSpeakingRate = 0.25
+	// Slowdown is how much slower each successive slow replay is.
+	Slowdown = 0.75
+)
+
+// SlowRate returns the speaking rate for the n-th slow replay of a word spoken at rate,
+// getting slower with every replay down to MinSpeakingRate.
+func SlowRate(rate float64, n int) float64 {
+	for range n {
+		rate *= Slowdown
+	}
+	return max(rate, MinSpeakingRate)
+}
+
+// SayWordSlowly speaks a word for the n-th time in a row at a reduced speed, see SlowRate.
+// The slow clip is synthesized at the lower speaking rate, and cached separately from the normal one.
+// If that fails, e.g. when offline, the normal clip is played back slowed down instead.
+func (t *TTS) SayWordSlowly(word string, n int) error {
+	turn, last := t.begin()
+
+	if clip, ok := t.Recordings[word]; ok {
+		// a recording can only be slowed down by resampling
+		return t.playTurn(turn, "", clip, SlowRate(1, n))
+	}
+
+	voice := t.Voice
+	voice.SpeakingRate = SlowRate(t.Voice.SpeakingRate, n)
+
+	req, err := t.requestWith(word, voice)
+	if err != nil {
+		return fmt.Errorf("error building speech request: %w", err)
+	}
+
+	audio, err := t.synthesize(req)
+	if err == nil {
+		return t.playTurn(turn, "", audio, 1)
+	}
+
+	if last.Word != word || len(last.AudioContent) == 0 {
+		return fmt.Errorf("error synthesizing slow speech: %w", err)
+	}
+	// resampling slows the clip down without the API, at the cost of a lower pitch
+	return t.playTurn(turn, "", last.AudioContent, voice.SpeakingRate/t.Voice.SpeakingRate)
+}
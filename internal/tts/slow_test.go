@@ -0,0 +1,124 @@
+package tts_test
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"testing"
+
+	"github.com/jharlan-hash/gospell/internal/audio"
+	"github.com/jharlan-hash/gospell/internal/tts"
+)
+
+func TestSlowRate(t *testing.T) {
+	tests := []struct {
+		name string // description of this test case
+		rate float64
+		n    int
+		want float64
+	}{
+		{"TestFirstReplay", 1.0, 1, 0.75},
+		{"TestSecondReplay", 1.0, 2, 0.5625},
+		{"TestFastVoice", 2.0, 1, 1.5},
+		{"TestClampsToMinimum", 1.0, 10, tts.MinSpeakingRate},
+		{"TestNoReplay", 1.0, 0, 1.0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tts.SlowRate(tt.rate, tt.n); got != tt.want {
+				t.Errorf("SlowRate() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestTTS_SayWordSlowly(t *testing.T) {
+	synth := &recordingSynthesizer{}
+	speech := &tts.TTS{Synthesizer: synth, Voice: tts.DefaultVoice, Ctx: context.Background()}
+
+	for n, want := range []float64{0.75, 0.5625} {
+		if err := speech.SayWordSlowly("example", n+1); err != nil {
+			t.Fatalf("SayWordSlowly(%d) error = %v", n+1, err)
+		}
+		if got := synth.last.Voice.SpeakingRate; got != want {
+			t.Errorf("SayWordSlowly(%d) speaking rate = %v, want %v", n+1, got, want)
+		}
+	}
+	if speech.Voice.SpeakingRate != tts.DefaultVoice.SpeakingRate {
+		t.Errorf("SayWordSlowly() changed the voice's speaking rate to %v", speech.Voice.SpeakingRate)
+	}
+}
+
+// flakySynthesizer returns clip on its first call and fails on every call after that.
+type flakySynthesizer struct {
+	clip  []byte
+	calls int
+}
+
+func (s *flakySynthesizer) SynthesizeSpeech(ctx context.Context, req tts.Request) ([]byte, error) {
+	s.calls++
+	if s.calls > 1 {
+		return nil, errors.New("offline")
+	}
+	return s.clip, nil
+}
+
+// speedPlayer records the speed of the clips it is asked to play.
+type speedPlayer struct {
+	audio.Discard
+	speeds []float64
+}
+
+func (p *speedPlayer) Play(clip []byte, speed float64) error {
+	p.speeds = append(p.speeds, speed)
+	return nil
+}
+
+func TestTTS_SayWordSlowlyOffline(t *testing.T) {
+	player := &speedPlayer{}
+	speech := &tts.TTS{
+		Synthesizer: &flakySynthesizer{clip: []byte("RIFF")},
+		Player:      player,
+		Voice:       tts.DefaultVoice,
+		Ctx:         context.Background(),
+	}
+
+	if err := speech.SayWord("example"); err != nil {
+		t.Fatalf("SayWord() error = %v", err)
+	}
+	if err := speech.SayWordSlowly("example", 1); err != nil {
+		t.Fatalf("SayWordSlowly() error = %v", err)
+	}
+
+	want := []float64{1, 0.75}
+	if !slices.Equal(player.speeds, want) {
+		t.Errorf("played at speeds %v, want %v", player.speeds, want)
+	}
+}
+
+func TestTTS_Recordings(t *testing.T) {
+	synth := &flakySynthesizer{clip: []byte("RIFF")}
+	player := &speedPlayer{}
+	speech := &tts.TTS{
+		Synthesizer: synth,
+		Player:      player,
+		Voice:       tts.DefaultVoice,
+		Recordings:  map[string][]byte{"colonel": []byte("OggS")},
+		Ctx:         context.Background(),
+	}
+
+	speech.Prefetch("colonel")
+	if err := speech.SayWord("colonel"); err != nil {
+		t.Fatalf("SayWord() error = %v", err)
+	}
+	if err := speech.SayWordSlowly("colonel", 2); err != nil {
+		t.Fatalf("SayWordSlowly() error = %v", err)
+	}
+
+	if synth.calls != 0 {
+		t.Errorf("synthesized %d times, want the recording played instead", synth.calls)
+	}
+	if want := []float64{1, 0.5625}; !slices.Equal(player.speeds, want) {
+		t.Errorf("played at speeds %v, want %v", player.speeds, want)
+	}
+}
@@ -0,0 +1,59 @@
+package tts
+
+import (
+	"bytes"
+	"encoding/xml"
+	"text/template"
+)
+
+// BeeTemplate is the default spelling-bee utterance: the word, a sentence using it, then the word again.
+// Without an example sentence the definition is read instead.
+const BeeTemplate = `<speak>{{.Word}}.<break time="800ms"/>{{or .Sentence .Definition}}<break time="800ms"/>{{.Word}}.</speak>`
+
+// Utterance is what a Template has to work with when speaking a word.
+type Utterance struct {
+	Word       string
+	Sentence   string // an example sentence using the word, if known
+	Definition string // the word's first definition, if known
+}
+
+// ParseTemplate parses an SSML template for spoken words.
+// Templates are executed with an Utterance whose fields are already XML-escaped,
+// so the template itself is written as plain SSML.
+func ParseTemplate(text string) (*template.Template, error) {
+	return template.New("utterance").Parse(text)
+}
+
+// request builds the synthesis request for a word in the configured voice.
+func (t *TTS) request(word string) (Request, error) {
+	return t.requestWith(word, t.Voice)
+}
+
+// requestWith builds the synthesis request for a word in the given voice.
+// With a Template set the word is spoken as SSML, otherwise as plain text.
+func (t *TTS) requestWith(word string, voice Voice) (Request, error) {
+	if t.Template == nil {
+		return Request{Text: word, Voice: voice}, nil
+	}
+
+	u := Utterance{Word: word}
+	if t.Describe != nil {
+		u = t.Describe(t.Ctx, word)
+	}
+
+	var buf bytes.Buffer
+	if err := t.Template.Execute(&buf, Utterance{
+		Word:       escapeXML(u.Word),
+		Sentence:   escapeXML(u.Sentence),
+		Definition: escapeXML(u.Definition),
+	}); err != nil {
+		return Request{}, err
+	}
+	return Request{Text: buf.String(), SSML: true, Voice: voice}, nil
+}
+
+func escapeXML(s string) string {
+	var buf bytes.Buffer
+	xml.EscapeText(&buf, []byte(s)) // writes to a bytes.Buffer never fail
+	return buf.String()
+}
@@ -0,0 +1,83 @@
+package tts_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/jharlan-hash/gospell/internal/tts"
+)
+
+// recordingSynthesizer remembers the last request it was asked to synthesize.
+type recordingSynthesizer struct {
+	last tts.Request
+}
+
+func (s *recordingSynthesizer) SynthesizeSpeech(ctx context.Context, req tts.Request) ([]byte, error) {
+	s.last = req
+	return nil, nil
+}
+
+func TestTTS_Template(t *testing.T) {
+	tests := []struct {
+		name      string // description of this test case
+		template  string
+		utterance tts.Utterance
+		want      string
+	}{
+		{"TestBeeWithSentence", tts.BeeTemplate, tts.Utterance{Word: "example", Sentence: "This is an example.", Definition: "a representative form"},
+			`<speak>example.<break time="800ms"/>This is an example.<break time="800ms"/>example.</speak>`},
+		{"TestBeeFallsBackToDefinition", tts.BeeTemplate, tts.Utterance{Word: "example", Definition: "a representative form"},
+			`<speak>example.<break time="800ms"/>a representative form<break time="800ms"/>example.</speak>`},
+		{"TestEscapesMarkup", `<speak>{{.Word}} {{.Definition}}</speak>`, tts.Utterance{Word: "and", Definition: "used to connect <this> & that"},
+			`<speak>and used to connect &lt;this&gt; &amp; that</speak>`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tmpl, err := tts.ParseTemplate(tt.template)
+			if err != nil {
+				t.Fatalf("ParseTemplate() error = %v", err)
+			}
+
+			synth := &recordingSynthesizer{}
+			speech := &tts.TTS{
+				Synthesizer: synth,
+				Voice:       tts.DefaultVoice,
+				Template:    tmpl,
+				Describe:    func(context.Context, string) tts.Utterance { return tt.utterance },
+				Ctx:         context.Background(),
+			}
+			if err := speech.SayWord(tt.utterance.Word); err != nil {
+				t.Fatalf("SayWord() error = %v", err)
+			}
+
+			if !synth.last.SSML {
+				t.Errorf("request is not marked as SSML")
+			}
+			if synth.last.Text != tt.want {
+				t.Errorf("request text = %q, want %q", synth.last.Text, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseTemplate_Invalid(t *testing.T) {
+	if _, err := tts.ParseTemplate(`<speak>{{.Word</speak>`); err == nil {
+		t.Errorf("ParseTemplate() of a broken template = nil, want error")
+	}
+}
+
+func TestTTS_SaySentence(t *testing.T) {
+	synth := &recordingSynthesizer{}
+	tmpl, err := tts.ParseTemplate(tts.BeeTemplate)
+	if err != nil {
+		t.Fatal(err)
+	}
+	speech := &tts.TTS{Synthesizer: synth, Voice: tts.DefaultVoice, Template: tmpl, Ctx: context.Background()}
+
+	if err := speech.SaySentence("The cat sat on the mat."); err != nil {
+		t.Fatalf("SaySentence() error = %v", err)
+	}
+	if synth.last.Text != "The cat sat on the mat." || synth.last.SSML {
+		t.Errorf("SaySentence() requested %+v, want the sentence as plain text, not through the template", synth.last)
+	}
+}
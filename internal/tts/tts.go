@@ -1,26 +1,34 @@
 package tts
 
 import (
-	"bytes"
 	"context"
-	"errors"
 	"fmt"
+	"sync"
+	"text/template"
 
-	"log"
-	"time"
-
-	texttospeech "cloud.google.com/go/texttospeech/apiv1"
-	"cloud.google.com/go/texttospeech/apiv1/texttospeechpb"
-	"github.com/gopxl/beep"
-	"github.com/gopxl/beep/speaker"
-	"github.com/gopxl/beep/wav"
+	"github.com/jharlan-hash/gospell/internal/audio"
 )
 
+// Synthesizer is a speech backend that turns text into audio in the requested Encoding.
+// Implementations may return empty audio, in which case nothing is played.
+type Synthesizer interface {
+	SynthesizeSpeech(ctx context.Context, req Request) ([]byte, error)
+}
+
 type TTS struct {
-	Client *texttospeech.Client
-	Ctx    context.Context
-	Word   string
-	audio  audioMessage
+	Synthesizer Synthesizer
+	Player      audio.Player // optional; nil plays nothing
+	Cache       *Cache       // optional; nil disables the on-disk cache
+	Voice       Voice
+	Concurrency int                                              // maximum number of concurrent prefetches; 0 means DefaultConcurrency
+	Template    *template.Template                               // optional SSML template, see ParseTemplate
+	Describe    func(ctx context.Context, word string) Utterance // supplies the sentence and definition for Template; called with Ctx
+	Recordings  map[string][]byte                                // optional recorded pronunciations by word, played instead of synthesized speech
+	Ctx         context.Context
+	mu          sync.Mutex // guards audio and turn; never held while waiting on the backend
+	audio       audioMessage
+	turn        int // counts the calls that speak; only the latest one plays
+	prefetch    prefetchState
 }
 
 type audioMessage struct {
@@ -28,74 +36,91 @@ type audioMessage struct {
 	Word         string
 }
 
-// SayWord takes a word and uses the Google Cloud Text-to-Speech API to generate and play the audio for that word.
+// SayWord uses the configured Synthesizer to generate and play the audio for a word.
+// It is safe to call from several goroutines; a later call interrupts earlier ones,
+// which return without playing once their audio arrives.
 // It checks if the audio for the word is already generated and stored in the audioMessage struct.
-// If the audio is already generated, it plays the audio directly without calling the API again.
-// If the audio is not generated, it calls the API to synthesize the speech and then plays the audio.
-func (t *TTS) SayWord() error {
-	// call tts api only if not already done
-	if t.audio.Word != t.Word {
-		audioContent, err := t.synthesizeSpeech()
-		if err != nil {
-			return errors.New(fmt.Sprintf("error synthesizing speech: %v", err))
-		}
-		t.audio.AudioContent = audioContent
-		t.audio.Word = t.Word
+// If the audio is already generated, it plays the audio directly without calling the backend again.
+// Otherwise it uses audio from Prefetch or the on-disk cache, and only then asks the backend to synthesize the speech.
+// Words with a recording are never synthesized.
+func (t *TTS) SayWord(word string) error {
+	turn, last := t.begin()
+
+	if clip, ok := t.Recordings[word]; ok {
+		return t.playTurn(turn, word, clip, 1)
 	}
-	// play the audio
-	t.PlayAudio()
-	return nil
-}
 
-func (t *TTS) PlayAudio() {
-	r := bytes.NewReader(t.audio.AudioContent)
-	// Play the audio
-	streamer, format, err := wav.Decode(r)
+	// call the backend only if not already done
+	if last.Word == word {
+		return t.playTurn(turn, word, last.AudioContent, 1)
+	}
+	audioContent, err := t.fetch(word, t.Voice)
 	if err != nil {
-		log.Fatal(err)
+		return err
 	}
-	defer streamer.Close()
-
-	sr := format.SampleRate
-	speaker.Init(sr, sr.N(time.Second/10))
-
-	done := make(chan bool)
-	speaker.Play(beep.Seq(streamer, beep.Callback(func() {
-		done <- true
-	})))
-	<-done
+	return t.playTurn(turn, word, audioContent, 1)
 }
 
-// synthesizeSpeech uses the Google Cloud Text-to-Speech API to synthesize speech from the given word.
-// It creates a request with the word, voice parameters, and audio configuration.
-// It then calls the API and returns the synthesized audio content.
-func (t *TTS) synthesizeSpeech() ([]byte, error) {
-	// set up request
-	req := texttospeechpb.SynthesizeSpeechRequest{
-		Input: &texttospeechpb.SynthesisInput{
-			InputSource: &texttospeechpb.SynthesisInput_Text{
-				Text: t.Word,
-			},
-		},
-		// configure voice
-		Voice: &texttospeechpb.VoiceSelectionParams{
-			LanguageCode: "en-US",
-			Name:         "en-US-Chirp3-HD-Fenrir", // this one makes me laugh bc he's zesty
-			// Name: "en-US-Wavenet-D", // this one is more natural
-			// Name: "en-US-Neural2-J", // this is the og
-			SsmlGender: texttospeechpb.SsmlVoiceGender_MALE,
-		},
-		// Configure the audio output
-		AudioConfig: &texttospeechpb.AudioConfig{
-			AudioEncoding: texttospeechpb.AudioEncoding_LINEAR16, // WAV format
-		},
+// synthesize returns the audio for a request, preferring the on-disk cache over the backend.
+// Freshly synthesized audio is written back to the cache.
+func (t *TTS) synthesize(req Request) ([]byte, error) {
+	if t.Cache != nil {
+		if audio, ok := t.Cache.Get(req); ok {
+			return audio, nil
+		}
 	}
 
-	// Call the API
-	resp, err := t.Client.SynthesizeSpeech(t.Ctx, &req)
+	audio, err := t.Synthesizer.SynthesizeSpeech(t.Ctx, req)
 	if err != nil {
 		return nil, err
 	}
 
-	return resp.AudioContent, nil
+	if t.Cache != nil && len(audio) > 0 {
+		// the cache is best-effort; a failed write only costs another API call later
+		_ = t.Cache.Put(req, audio)
+	}
+	return audio, nil
+}
+
+// PlayAudio plays the audio of the last word spoken again.
+func (t *TTS) PlayAudio() error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.turn++
+	return t.play(t.audio.AudioContent, 1)
+}
+
+// begin starts a turn to speak, superseding all earlier ones, and returns it with the last word spoken.
+// The caller then fetches its audio without holding mu, so that a slow backend doesn't hold up later turns.
+func (t *TTS) begin() (int, audioMessage) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.turn++
+	return t.turn, t.audio
+}
+
+// playTurn plays a clip unless a later turn has started since, and remembers it for PlayAudio if it is a word.
+func (t *TTS) playTurn(turn int, word string, clip []byte, speed float64) error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if turn != t.turn {
+		return nil // interrupted before the audio arrived
+	}
+	if word != "" {
+		t.audio = audioMessage{AudioContent: clip, Word: word}
+	}
+	return t.play(clip, speed)
+}
+
+// play hands a clip to the Player, interrupting whatever it is playing.
+// Speed 1 is normal speed and 0.5 half speed.
+func (t *TTS) play(clip []byte, speed float64) error {
+	// silent backends produce no audio
+	if t.Player == nil || len(clip) == 0 {
+		return nil
+	}
+	if err := t.Player.Play(clip, speed); err != nil {
+		return fmt.Errorf("error playing audio: %w", err)
+	}
+	return nil
 }
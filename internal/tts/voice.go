@@ -0,0 +1,78 @@
+package tts
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+)
+
+// Encoding is the audio format requested from a Synthesizer.
+// Values match the Google Cloud AudioEncoding names.
+type Encoding string
+
+const (
+	Linear16 Encoding = "LINEAR16" // uncompressed WAV
+	MP3      Encoding = "MP3"      // MP3 at 32 kbps, about a tenth of the size of WAV
+	OggOpus  Encoding = "OGG_OPUS" // Opus in an Ogg container, the smallest of the three
+)
+
+// Voice describes how a piece of text should be spoken.
+// Use `gospell --list-voices` to see which names and language codes are available.
+type Voice struct {
+	Name         string   `json:"name"`
+	LanguageCode string   `json:"language_code"`
+	Gender       string   `json:"gender"`         // MALE, FEMALE, NEUTRAL or empty for any
+	SpeakingRate float64  `json:"speaking_rate"`  // 0.25 to 4.0, 1.0 is normal speed
+	Pitch        float64  `json:"pitch"`          // -20.0 to 20.0 semitones
+	VolumeGainDb float64  `json:"volume_gain_db"` // -96.0 to 16.0 dB
+	Encoding     Encoding `json:"encoding"`
+}
+
+// DefaultVoice is the voice gospell uses unless told otherwise.
+var DefaultVoice = Voice{
+	Name:         "en-US-Chirp3-HD-Fenrir", // this one makes me laugh bc he's zesty
+	LanguageCode: "en-US",
+	Gender:       "MALE",
+	SpeakingRate: 1.0,
+	Encoding:     Linear16,
+}
+
+// Validate reports whether the voice settings are within the ranges the API accepts.
+func (v Voice) Validate() error {
+	switch v.Encoding {
+	case Linear16, MP3, OggOpus:
+	default:
+		return fmt.Errorf("unknown encoding %q: want LINEAR16, MP3 or OGG_OPUS", v.Encoding)
+	}
+	switch v.Gender {
+	case "", "MALE", "FEMALE", "NEUTRAL":
+	default:
+		return fmt.Errorf("unknown gender %q: want MALE, FEMALE or NEUTRAL", v.Gender)
+	}
+	if v.SpeakingRate < 0.25 || v.SpeakingRate > 4.0 {
+		return fmt.Errorf("speaking rate %v out of range [0.25, 4.0]", v.SpeakingRate)
+	}
+	if v.Pitch < -20.0 || v.Pitch > 20.0 {
+		return fmt.Errorf("pitch %v out of range [-20.0, 20.0]", v.Pitch)
+	}
+	if v.VolumeGainDb < -96.0 || v.VolumeGainDb > 16.0 {
+		return fmt.Errorf("volume gain %v dB out of range [-96.0, 16.0]", v.VolumeGainDb)
+	}
+	return nil
+}
+
+// Request is a single piece of text to synthesize with a given voice.
+type Request struct {
+	Text  string `json:"text"`
+	SSML  bool   `json:"ssml,omitempty"` // Text is an SSML document rather than plain text
+	Voice Voice  `json:"voice"`
+}
+
+// Key returns the content address of the audio for a request.
+// Two requests share a key exactly when they would produce the same audio.
+func (r Request) Key() string {
+	b, _ := json.Marshal(r) // Request only holds strings and numbers, so this cannot fail
+	sum := sha256.Sum256(b)
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,140 @@
+package main
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"slices"
+	"strings"
+
+	"github.com/jharlan-hash/gospell/internal/api"
+	"github.com/jharlan-hash/gospell/internal/curate"
+	"github.com/jharlan-hash/gospell/internal/definition"
+	"github.com/pborman/getopt"
+)
+
+// listsCommand runs "gospell lists ..." with the arguments after "lists" and returns the exit status.
+func listsCommand(args []string) int {
+	if len(args) == 0 || args[0] != "lint" {
+		fmt.Fprintln(os.Stderr, "usage: gospell lists lint [--fix] [--no-dictionary] [file ...]")
+		return 2
+	}
+	return lintCommand(args)
+}
+
+// lintCommand checks plain text word lists, or the embedded list if no file is given and stdin for "-",
+// and prints every word it would remove. With --fix it rewrites the files without them.
+// It returns 1 if words were found and not fixed.
+func lintCommand(args []string) int {
+	set := getopt.New()
+	set.SetProgram("gospell lists lint")
+	set.SetParameters("[file ...]")
+
+	var fix, noDictionary, help bool
+	set.BoolVarLong(&fix, "fix", 0, "rewrite the files without the removed words")
+	set.BoolVarLong(&noDictionary, "no-dictionary", 0, "keep words without a definition in the embedded dictionary")
+	set.BoolVarLong(&help, "help", 'h', "display help")
+	if err := set.Getopt(args, nil); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		set.PrintUsage(os.Stderr)
+		return 2
+	}
+	if help {
+		set.PrintUsage(os.Stdout)
+		return 0
+	}
+
+	linter := curate.Linter{}
+	if !noDictionary {
+		linter.Dictionary = definition.LoadCache()
+		if len(linter.Dictionary) == 0 {
+			fmt.Fprintln(os.Stderr, "warning: the embedded dictionary is empty, keeping words without a definition")
+		}
+	}
+
+	paths := set.Args()
+	if len(paths) == 0 {
+		if fix {
+			fmt.Fprintln(os.Stderr, "--fix needs the path of a word list, e.g. internal/api/wordlist.txt")
+			return 2
+		}
+		paths = []string{""}
+	}
+
+	if fix && slices.Contains(paths, "-") {
+		fmt.Fprintln(os.Stderr, "--fix can't rewrite stdin")
+		return 2
+	}
+
+	status := 0
+	for _, path := range paths {
+		removed, err := lintFile(linter, path, fix, os.Stdout)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			return 2
+		}
+		if removed > 0 && !fix {
+			status = 1
+		}
+	}
+	return status
+}
+
+// lintFile lints the word list at path, stdin if path is "-" or the embedded list if it is empty,
+// and reports to w. It returns the number of words removed.
+func lintFile(linter curate.Linter, path string, fix bool, w io.Writer) (int, error) {
+	var content []byte
+	var err error
+	name := path
+	switch path {
+	case "":
+		content, name = []byte(api.EmbeddedText()), "wordlist.txt"
+	case "-":
+		content, err = io.ReadAll(os.Stdin)
+		name = "stdin"
+	default:
+		content, err = os.ReadFile(path)
+	}
+	if err != nil {
+		return 0, err
+	}
+
+	kept, removed, err := linter.Lint(bytes.NewReader(content))
+	if err != nil {
+		return 0, fmt.Errorf("%s: %w", name, err)
+	}
+
+	counts := make(map[curate.Reason]int)
+	for _, r := range removed {
+		counts[r.Reason]++
+		fmt.Fprintf(w, "%s:%d: %q: %s\n", name, r.Line, r.Word, r.Reason)
+	}
+
+	var summary []string
+	for _, reason := range curate.Reasons {
+		if counts[reason] > 0 {
+			summary = append(summary, fmt.Sprintf("%d %s", counts[reason], reason))
+		}
+	}
+	verb := "would remove"
+	if fix {
+		verb = "removed"
+	}
+	if len(removed) == 0 {
+		fmt.Fprintf(w, "%s: %d words, nothing to remove\n", name, len(kept))
+	} else {
+		fmt.Fprintf(w, "%s: %s %d of %d lines (%s), %d words left\n", name, verb, len(removed), len(kept)+len(removed), strings.Join(summary, ", "), len(kept))
+	}
+
+	if fix && len(removed) > 0 {
+		if len(kept) == 0 {
+			return 0, errors.New(name + ": refusing to write an empty word list")
+		}
+		if err := os.WriteFile(path, []byte(strings.Join(kept, "\n")+"\n"), 0o644); err != nil {
+			return 0, err
+		}
+	}
+	return len(removed), nil
+}
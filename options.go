@@ -0,0 +1,138 @@
+package main
+
+import (
+	"errors"
+	"io/fs"
+	"log"
+	"os"
+	"strconv"
+
+	"github.com/jharlan-hash/gospell/internal/config"
+	"github.com/pborman/getopt"
+)
+
+// floatValue is a getopt.Value for float64 flags, which getopt doesn't provide.
+type floatValue float64
+
+func (f *floatValue) Set(value string, opt getopt.Option) error {
+	v, err := strconv.ParseFloat(value, 64)
+	if err != nil {
+		return errors.New("invalid value for " + opt.Name() + ": " + strconv.Quote(value))
+	}
+	*f = floatValue(v)
+	return nil
+}
+
+func (f *floatValue) String() string {
+	return strconv.FormatFloat(float64(*f), 'g', -1, 64)
+}
+
+// seedValue is a getopt.Value for --seed. It sets an optional seed, so that --seed=0 is a seed like any other.
+type seedValue struct {
+	seed **int64
+}
+
+func (s seedValue) Set(value string, opt getopt.Option) error {
+	v, err := strconv.ParseInt(value, 10, 64)
+	if err != nil {
+		return errors.New("invalid value for " + opt.Name() + ": " + strconv.Quote(value))
+	}
+	*s.seed = &v
+	return nil
+}
+
+func (s seedValue) String() string {
+	if *s.seed == nil {
+		return ""
+	}
+	return strconv.FormatInt(**s.seed, 10)
+}
+
+// options are the settings for a run of gospell.
+type options struct {
+	config.Config
+	listVoices bool
+}
+
+// parseOptions reads the config file and applies the command-line flags on top of it.
+// It exits the program on --help or if the settings are invalid.
+func parseOptions() options {
+	opts := options{Config: config.Default()}
+	cfg := &opts.Config
+
+	var configPath string
+	var wordlists, packs []string
+	var help bool
+
+	getopt.StringVarLong(&configPath, "config", 0, "path to config file (default $XDG_CONFIG_HOME/gospell/config.json)")
+	getopt.StringVarLong(&cfg.Credentials, "credentials", 'c', "Path to Google Cloud credentials JSON file (optional)")
+	getopt.StringVarLong(&cfg.Endpoint, "endpoint", 0, "host:port of a TextToSpeech API to use instead of Google's; without --credentials it is used unauthenticated")
+	getopt.BoolVarLong(&cfg.Silent, "silent", 's', "run without text-to-speech")
+	getopt.BoolVarLong(&cfg.NoCache, "no-cache", 0, "don't read or write the on-disk caches of audio and definitions")
+	getopt.IntVarLong(&cfg.Prefetch, "prefetch", 0, "number of upcoming words to synthesize ahead of time")
+	getopt.StringVarLong(&cfg.Voice.Name, "voice", 0, "name of the voice to speak with, see --list-voices")
+	getopt.StringVarLong(&cfg.Voice.LanguageCode, "language", 0, "language code of the voice, e.g. en-GB")
+	getopt.StringVarLong(&cfg.Voice.Gender, "gender", 0, "gender of the voice: MALE, FEMALE or NEUTRAL")
+	getopt.VarLong((*floatValue)(&cfg.Voice.SpeakingRate), "rate", 0, "speaking rate, 0.25 to 4.0")
+	getopt.VarLong((*floatValue)(&cfg.Voice.Pitch), "pitch", 0, "pitch in semitones, -20.0 to 20.0")
+	getopt.VarLong((*floatValue)(&cfg.Voice.VolumeGainDb), "volume-gain", 0, "volume gain in dB, -96.0 to 16.0")
+	getopt.EnumVarLong((*string)(&cfg.Voice.Encoding), "encoding", 0, []string{"LINEAR16", "MP3", "OGG_OPUS"}, "audio encoding to request and cache: LINEAR16, MP3 or OGG_OPUS")
+	getopt.ListVarLong(&wordlists, "wordlist", 'w', "word list to practice from: plain text, CSV, TSV or JSON, \"-\" for stdin; repeat to combine lists")
+	getopt.ListVarLong(&packs, "pack", 'p', "word pack to practice from, see gospell packs list; repeat to combine packs")
+	getopt.VarLong(seedValue{&cfg.Seed}, "seed", 0, "seed for the sequence of words; the same seed and lists give the same session")
+	getopt.BoolVarLong(&cfg.Shuffle, "shuffle", 0, "don't repeat a word until every word of the list came up")
+	getopt.EnumVarLong(&cfg.Band, "band", 0, []string{"common", "uncommon", "rare"}, "frequency band to practice: common (top 5k), uncommon (5k-20k) or rare")
+	getopt.BoolVarLong(&cfg.WeightByFrequency, "weight-frequency", 0, "pick frequent words more often than rare ones")
+	getopt.EnumVarLong(&cfg.Homophones, "homophones", 0, []string{"define", "accept", "skip"}, "words that sound like others (there, their): define them up front, accept any of them, or skip them")
+	getopt.BoolVarLong(&cfg.Drill, "drill", 0, "drill word families: a word, then its plural, past tense, -ing form and so on")
+	getopt.IntVarLong(&cfg.Level, "level", 'l', "difficulty level from 1 (beginner) to 5 (bee champion); overrides --min-difficulty and --max-difficulty")
+	getopt.VarLong((*floatValue)(&cfg.MinDifficulty), "min-difficulty", 0, "easiest difficulty score to practice, 0 to 100")
+	getopt.VarLong((*floatValue)(&cfg.MaxDifficulty), "max-difficulty", 0, "hardest difficulty score to practice, 0 to 100")
+	getopt.BoolVarLong(&cfg.NoReview, "no-review", 0, "don't record attempts or bring back missed words for review")
+	getopt.StringVarLong(&cfg.ReviewFile, "review-file", 0, "path to the review schedule (default $XDG_CONFIG_HOME/gospell/reviews.json)")
+	getopt.VarLong((*floatValue)(&cfg.ReviewRatio), "review-ratio", 0, "share of words that are due reviews, 0 to 1")
+	getopt.BoolVarLong(&cfg.Online, "online", 0, "look words the dictionaries lack up in an online dictionary")
+	getopt.StringVarLong(&cfg.DefinitionsURL, "definitions-url", 0, "dictionaryapi.dev-compatible API for --online")
+	getopt.StringVarLong(&cfg.DefinitionsFile, "definitions-file", 0, "path to your own definitions (default $XDG_CONFIG_HOME/gospell/definitions.json)")
+	getopt.EnumVarLong(&cfg.MissingDefinitions, "missing-definitions", 0, []string{"show", "skip", "fallback", "sentence"}, "words without definitions: say so, skip them, look them up online, or show their example sentence instead")
+	getopt.BoolVarLong(&cfg.NoMask, "no-mask", 0, "don't blank the word and its forms out of definitions before you answer")
+	getopt.BoolVarLong(&cfg.SpeakSentences, "speak-sentences", 0, "read example sentences aloud when you ask for one with CtrlE")
+	getopt.BoolVarLong(&cfg.Bee, "bee", 'b', "spelling-bee mode: say the word, use it in a sentence, say it again")
+	getopt.StringVarLong(&cfg.BeeTemplateFile, "bee-template", 0, "path to a custom SSML template for --bee")
+	getopt.BoolVarLong(&opts.listVoices, "list-voices", 0, "list the voices available for --language and exit")
+	getopt.BoolVarLong(&help, "help", 'h', "display help")
+
+	getopt.Parse()
+
+	if help {
+		getopt.Usage()
+		os.Exit(0)
+	}
+
+	// A missing config file is only an error if the user asked for it by name.
+	explicit := configPath != ""
+	if !explicit {
+		configPath, _ = config.DefaultPath()
+	}
+	if configPath != "" {
+		if err := cfg.Load(configPath); err != nil && (explicit || !errors.Is(err, fs.ErrNotExist)) {
+			log.Fatal(err)
+		}
+	}
+
+	// Flags take precedence over the config file, so parse them again on top of it.
+	// List flags append, so start the word lists over rather than doubling them.
+	wordlists, packs = nil, nil
+	getopt.Parse()
+	if len(wordlists) > 0 {
+		cfg.Wordlists = wordlists
+	}
+	if len(packs) > 0 {
+		cfg.Packs = packs
+	}
+
+	if err := cfg.Validate(); err != nil {
+		log.Fatal(err)
+	}
+	return opts
+}
@@ -0,0 +1,26 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/pborman/getopt"
+)
+
+func TestSeedValue(t *testing.T) {
+	var seed *int64
+	v := seedValue{&seed}
+	if v.String() != "" {
+		t.Errorf("String() of an unset seed = %q, want \"\"", v.String())
+	}
+
+	opt := getopt.New().VarLong(v, "seed", 0, "")
+	if err := v.Set("0", opt); err != nil {
+		t.Fatal(err)
+	}
+	if seed == nil || *seed != 0 {
+		t.Errorf("--seed=0 left the seed %v, want it set to 0", seed)
+	}
+	if err := v.Set("zero", opt); err == nil {
+		t.Error("Set(\"zero\") succeeded, want an error")
+	}
+}
@@ -0,0 +1,58 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"text/tabwriter"
+
+	"github.com/jharlan-hash/gospell/internal/pack"
+)
+
+const packsUsage = "usage: gospell packs list | gospell packs install DIR"
+
+// packsCommand runs "gospell packs ..." with the arguments after "packs" and returns the exit status.
+func packsCommand(args []string) int {
+	dir, err := pack.DefaultDir()
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return 2
+	}
+
+	switch {
+	case len(args) == 1 && args[0] == "list":
+		err = listPacks(dir, os.Stdout)
+	case len(args) == 2 && args[0] == "install":
+		var p *pack.Pack
+		if p, err = pack.Install(args[1], dir); err == nil {
+			fmt.Printf("installed %s %s, practice it with --pack=%s\n", p.Name, p.Version, p.Name)
+		}
+	default:
+		fmt.Fprintln(os.Stderr, packsUsage)
+		return 2
+	}
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return 1
+	}
+	return 0
+}
+
+// listPacks prints the embedded packs and those installed in dir to w.
+// Installed packs that can't be read are reported on stderr and left out.
+func listPacks(dir string, w io.Writer) error {
+	packs, broken, err := pack.All(dir)
+	if err != nil {
+		return err
+	}
+	for _, b := range broken {
+		fmt.Fprintln(os.Stderr, "warning: skipping broken pack:", b)
+	}
+
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "NAME\tVERSION\tSOURCE\tTITLE\tDESCRIPTION")
+	for _, p := range packs {
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%s\t%s\n", p.Name, p.Version, p.Source, p.Title, p.Description)
+	}
+	return tw.Flush()
+}
@@ -0,0 +1,18 @@
+package main
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestListPacks(t *testing.T) {
+	var out strings.Builder
+	if err := listPacks(t.TempDir(), &out); err != nil {
+		t.Fatal(err)
+	}
+	for _, want := range []string{"DESCRIPTION", "grade-5-bee", "Words at the level of a fifth grade school spelling bee."} {
+		if !strings.Contains(out.String(), want) {
+			t.Errorf("listPacks() = %q, want it to contain %q", out.String(), want)
+		}
+	}
+}
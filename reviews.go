@@ -0,0 +1,79 @@
+package main
+
+import (
+	"github.com/jharlan-hash/gospell/internal/api"
+	"github.com/jharlan-hash/gospell/internal/review"
+)
+
+// openReviews mixes the due reviews from the deck at path, or the default deck if path is empty,
+// into words at ratio.
+func openReviews(path string, words api.WordSource, ratio float64) (*review.Source, error) {
+	if path == "" {
+		// without a config dir reviews only last for this session
+		path, _ = review.DefaultPath()
+	}
+	deck, err := review.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	return review.NewSource(deck, words, ratio), nil
+}
+
+// mixReviews mixes the due reviews from the deck at path into lists at ratio.
+// In a drill they are mixed into the choice of families, so that they come up between families, never inside one.
+func mixReviews(path string, lists *wordlists, ratio float64) (*review.Source, error) {
+	if lists.Drill != nil {
+		reviews, err := openReviews(path, lists.Drill.Lemmas, ratio)
+		if err != nil {
+			return nil, err
+		}
+		lists.Drill.Lemmas = reviews
+		return reviews, nil
+	}
+
+	reviews, err := openReviews(path, lists.Words, ratio)
+	if err != nil {
+		return nil, err
+	}
+	lists.Words = reviews
+	return reviews, nil
+}
+
+// reviewSaveBatch is how many recorded attempts are saved at once.
+// The rest are saved when the session ends.
+const reviewSaveBatch = 10
+
+// recordAttempt records the user's answer for the current word, and saves the schedule every reviewSaveBatch attempts.
+// A failed save is shown in the status bar; the attempts are kept in memory and saved with the next batch.
+func (m *model) recordAttempt(answer string, correct bool) {
+	if m.reviews == nil {
+		return
+	}
+	m.reviews.Record(m.word, answer, correct)
+	m.unsavedReviews++
+	if m.unsavedReviews >= reviewSaveBatch {
+		if err := m.saveReviews(); err != nil {
+			m.status = "Couldn't save reviews: " + err.Error()
+		}
+	}
+}
+
+// saveReviews saves the review schedule if attempts were recorded since it was last saved.
+func (m *model) saveReviews() error {
+	if m.reviews == nil || m.unsavedReviews == 0 {
+		return nil
+	}
+	if err := m.reviews.Deck.Save(); err != nil {
+		return err
+	}
+	m.unsavedReviews = 0
+	return nil
+}
+
+// dueReviews returns how many reviews are due now.
+func (m *model) dueReviews() int {
+	if m.reviews == nil {
+		return 0
+	}
+	return len(m.reviews.Deck.Due(m.reviews.Now()))
+}
@@ -0,0 +1,98 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/jharlan-hash/gospell/internal/api"
+	"github.com/jharlan-hash/gospell/internal/morphology"
+	"github.com/jharlan-hash/gospell/internal/review"
+)
+
+func TestMixReviews_Drill(t *testing.T) {
+	// the words the user missed come due once the drill is under way
+	due := time.Date(2030, 1, 1, 0, 0, 0, 0, time.UTC)
+	path := filepath.Join(t.TempDir(), "reviews.json")
+	deck := `[{"word":"cat","attempts":[],"ease":2.5,"due":"2030-01-01T00:00:00Z"},` +
+		`{"word":"dog","attempts":[],"ease":2.5,"due":"2030-01-01T00:00:00Z"}]`
+	if err := os.WriteFile(path, []byte(deck), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	families := []morphology.Family{
+		{Lemma: "hop", Forms: []morphology.Form{{Word: "hops"}, {Word: "hopped"}, {Word: "hopping"}}},
+		{Lemma: "box", Forms: []morphology.Form{{Word: "boxes"}, {Word: "boxed"}}},
+	}
+	drill := morphology.NewDrill(families, api.NewList([]string{"hop", "box"}))
+	lists := &wordlists{Words: drill, Drill: drill}
+
+	reviews, err := mixReviews(path, lists, 1) // a review whenever one is due
+	if err != nil {
+		t.Fatal(err)
+	}
+	reviews.Seed(1)
+
+	now := due.Add(-time.Hour)
+	reviews.Now = func() time.Time { return now }
+	words := []string{lists.Words.Next()}
+	now = due
+	for range 20 {
+		words = append(words, lists.Words.Next())
+	}
+	for _, fam := range families {
+		start := slices.Index(words, fam.Lemma)
+		if start < 0 {
+			t.Fatalf("drew %v, want the %s family", words, fam.Lemma)
+		}
+		if got := words[start : start+len(fam.Words())]; !slices.Equal(got, fam.Words()) {
+			t.Errorf("drew %v, want the %s family in a row", words, fam.Lemma)
+		}
+	}
+	for _, word := range []string{"cat", "dog"} {
+		if !slices.Contains(words, word) {
+			t.Errorf("drew %v, want the review of %s between families", words, word)
+		}
+	}
+}
+
+func TestModel_recordAttempt(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "reviews.json")
+	reviews, err := openReviews(path, api.NewList([]string{"cat"}), 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	m := &model{reviews: reviews}
+
+	m.word = "cat"
+	m.recordAttempt("cat", true)
+	if c, ok := reviews.Deck.Cards["cat"]; !ok || len(c.Attempts) != 1 || c.Scheduled() {
+		t.Errorf("a correct answer to a new word = %+v, want it recorded but not scheduled", c)
+	}
+
+	for i := 1; i < reviewSaveBatch; i++ {
+		if _, err := os.Stat(path); err == nil {
+			t.Fatalf("saved after %d attempts, want a batch of %d", i, reviewSaveBatch)
+		}
+		m.word = string(rune('a' + i))
+		m.recordAttempt("?", false)
+	}
+	if _, err := os.Stat(path); err != nil {
+		t.Errorf("not saved after a batch of %d attempts: %v", reviewSaveBatch, err)
+	}
+
+	m.word = "cat"
+	m.recordAttempt("kat", false)
+	if err := m.saveReviews(); err != nil {
+		t.Fatal(err)
+	}
+	deck, err := review.Open(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := deck.Cards["cat"]; !ok {
+		t.Error("saveReviews() didn't save the change after the batch")
+	}
+}
@@ -0,0 +1,102 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/jharlan-hash/gospell/internal/definition"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// maxSpeechFailures is how many times in a row speech may fail before gospell
+// falls back to text-only mode.
+const maxSpeechFailures = 3
+
+// speechMessage reports how speaking a word went. A nil err means it was spoken.
+type speechMessage struct {
+	err error
+}
+
+// sayWord returns a command that speaks word in the background.
+// In text-only mode it does nothing.
+func (m *model) sayWord(word string) tea.Cmd {
+	if m.textOnly {
+		return nil
+	}
+	return func() tea.Msg {
+		return speechMessage{err: m.ttsState.SayWord(word)}
+	}
+}
+
+// sayWordSlowly returns a command that speaks word slowly for the n-th time.
+// In text-only mode it does nothing.
+func (m *model) sayWordSlowly(word string, n int) tea.Cmd {
+	if m.textOnly {
+		return nil
+	}
+	return func() tea.Msg {
+		return speechMessage{err: m.ttsState.SayWordSlowly(word, n)}
+	}
+}
+
+// handleSpeech updates the status bar after a word was spoken or failed to be.
+// After maxSpeechFailures failures in a row it switches to text-only mode,
+// so a flaky network never ends a session.
+func (m *model) handleSpeech(msg speechMessage) {
+	if msg.err == nil {
+		m.speechFailures = 0
+		m.status = ""
+		return
+	}
+	if errors.Is(msg.err, context.Canceled) {
+		return // quitting
+	}
+
+	m.speechFailures++
+	if m.speechFailures >= maxSpeechFailures {
+		m.textOnly = true
+		m.status = fmt.Sprintf("Speech unavailable, text-only mode ('CtrlT' to retry): %v", msg.err)
+		return
+	}
+	m.status = fmt.Sprintf("Speech error ('CtrlT' to retry): %v", msg.err)
+}
+
+// disableSpeech starts the session in text-only mode, telling the user why in the status bar.
+func (m *model) disableSpeech(reason string) {
+	m.textOnly = true
+	m.status = "Speech disabled, text-only mode: " + reason
+}
+
+// retrySpeech leaves text-only mode and speaks the current word again.
+func (m *model) retrySpeech() tea.Cmd {
+	m.textOnly = false
+	m.speechFailures = 0
+	m.status = "Retrying speech..."
+	m.prefetch(m.upcoming...)
+	return m.sayWord(m.word)
+}
+
+// prefetch starts synthesizing words in the background, so their audio is ready when they come up.
+// In definition.Skip mode each word is looked up first, so no speech is synthesized for words that are going to be skipped.
+// In text-only mode it does nothing.
+func (m *model) prefetch(words ...string) {
+	if m.textOnly || len(words) == 0 {
+		return
+	}
+	if m.missing != definition.Skip {
+		m.ttsState.Prefetch(words...)
+		return
+	}
+
+	// lookups may go to the network, so they run in the background too
+	state, speech := m.definitionState, m.ttsState
+	go func() {
+		for _, word := range words {
+			if result := state.LookUp(speech.Ctx, word); !errors.Is(result.Err, definition.ErrNotFound) {
+				speech.Prefetch(word)
+			}
+		}
+	}()
+}
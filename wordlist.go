@@ -0,0 +1,188 @@
+package main
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+
+	"github.com/jharlan-hash/gospell/internal/api"
+	"github.com/jharlan-hash/gospell/internal/config"
+	"github.com/jharlan-hash/gospell/internal/definition"
+	"github.com/jharlan-hash/gospell/internal/difficulty"
+	"github.com/jharlan-hash/gospell/internal/homophone"
+	"github.com/jharlan-hash/gospell/internal/morphology"
+	"github.com/jharlan-hash/gospell/internal/pack"
+)
+
+// wordlists are the words to practice and what the word lists say about them.
+type wordlists struct {
+	Words      api.WordSource
+	Entries    []api.Entry       // entries of the word list files and packs, with their definitions and sentences
+	Recordings map[string][]byte // recorded pronunciations from packs, by word
+	Drill      *morphology.Drill // the words as a drill of word families; nil unless cfg.Drill is set
+}
+
+// loadWordlists returns the words to practice from the word list files and packs in cfg,
+// or the embedded word list if there are none.
+// Only words whose difficulty and frequency band match the config are practiced, and of the embedded list
+// only the words dictionary defines, directly or through their lemma.
+func loadWordlists(cfg *config.Config, dictionary definition.Dictionary) (*wordlists, error) {
+	paths, level := cfg.Wordlists, cfg.Difficulty()
+
+	loaded := &wordlists{Recordings: make(map[string][]byte)}
+	var lists []*api.List
+	for _, path := range paths {
+		entries, err := api.LoadEntries(path)
+		if err != nil {
+			return nil, err
+		}
+		if len(entries) == 0 {
+			return nil, fmt.Errorf("word list %s has no words", path)
+		}
+		loaded.Entries = append(loaded.Entries, entries...)
+		lists = append(lists, api.NewList(api.Words(entries)))
+	}
+	if len(cfg.Packs) > 0 {
+		dir, err := pack.DefaultDir()
+		if err != nil {
+			return nil, err
+		}
+		for _, name := range cfg.Packs {
+			entries, err := loadPack(name, dir, loaded.Recordings)
+			if err != nil {
+				return nil, err
+			}
+			loaded.Entries = append(loaded.Entries, entries...)
+			lists = append(lists, api.NewList(api.Words(entries)))
+		}
+	}
+	var defined func(word string) bool // which words to keep; nil keeps them all
+	if len(lists) == 0 {
+		lists = append(lists, api.Embedded())
+		if len(dictionary) > 0 {
+			// your own lists may carry their own definitions, but the embedded one relies on the dictionary,
+			// which defines plurals and inflections through their lemma
+			defined = func(word string) bool { return dictionary.Headword(word) != "" }
+		}
+	}
+
+	frequency := api.EmbeddedFrequency()
+	scorer := difficulty.Scorer{Ranker: frequency}
+	band, _ := api.ParseBand(cfg.Band) // checked by cfg.Validate
+	homophones, skipHomophones := homophone.Embedded(), cfg.Homophones == string(homophone.Skip)
+	filter := func(list *api.List) *api.List {
+		if level == difficulty.All && band == api.AnyBand && !skipHomophones && defined == nil {
+			return list
+		}
+		return list.Filter(func(word string) bool {
+			return frequency.Contains(band, word) && level.Contains(scorer.Score(word)) &&
+				!(skipHomophones && homophones.Has(word)) && (defined == nil || defined(word))
+		})
+	}
+
+	if cfg.Drill {
+		// families are grouped from the lists as they are, and filtered by their lemma
+		drill, err := newDrill(lists, dictionary, func(lemmas *api.List) api.WordSource {
+			return pick(cfg, filter(lemmas), frequency)
+		})
+		if err != nil {
+			return nil, err
+		}
+		loaded.Words, loaded.Drill = drill, drill
+		return loaded, nil
+	}
+
+	// the lists are practiced as one, so that --shuffle deals every word of all of them once,
+	// and a word on several lists comes up no more often than the others
+	list := filter(api.Union(lists...))
+	if list.Len() == 0 {
+		if band != api.AnyBand {
+			return nil, fmt.Errorf("no %s words with a difficulty from %g to %g", band, level.Min, level.Max)
+		}
+		return nil, fmt.Errorf("no words with a difficulty from %g to %g", level.Min, level.Max)
+	}
+	loaded.Words = pick(cfg, list, frequency)
+	return loaded, nil
+}
+
+// pick returns a source that picks words from list the way cfg says: weighted by frequency, shuffled or at random.
+func pick(cfg *config.Config, list *api.List, frequency api.Frequency) api.WordSource {
+	switch {
+	case cfg.WeightByFrequency:
+		return api.NewWeighted(list.Words(), frequency.Weight)
+	case cfg.Shuffle:
+		return list.Shuffled()
+	}
+	return list
+}
+
+// loadPack returns the entries of the pack called name, installed in dir or embedded,
+// and adds its recordings to recordings.
+func loadPack(name, dir string, recordings map[string][]byte) ([]api.Entry, error) {
+	p, err := pack.Find(name, dir)
+	if err != nil {
+		return nil, err
+	}
+	entries, err := p.Entries()
+	if err != nil {
+		return nil, err
+	}
+	if len(entries) == 0 {
+		return nil, fmt.Errorf("pack %s has no words", name)
+	}
+	clips, err := p.Recordings()
+	if err != nil {
+		return nil, err
+	}
+	maps.Copy(recordings, clips)
+	return entries, nil
+}
+
+// readsStdin reports whether one of the word lists is piped to stdin.
+func readsStdin(paths []string) bool {
+	return slices.Contains(paths, "-")
+}
+
+// overrideDefinitions replaces the dictionary's definitions of words whose entry has its own definition.
+// An entry with only a part of speech narrows the dictionary's definitions down to it, e.g. lead as a noun.
+func overrideDefinitions(dictionary definition.Dictionary, entries []api.Entry) {
+	for _, entry := range entries {
+		switch {
+		case entry.Definition != "":
+			dictionary[entry.Word] = []definition.Entry{{
+				Word:            entry.Word,
+				DefinitionIndex: 1,
+				NumDefinitions:  1,
+				PartOfSpeech:    entry.PartOfSpeech,
+				Definition:      entry.Definition,
+			}}
+		case entry.PartOfSpeech != "":
+			var senses []definition.Entry
+			for _, e := range dictionary[entry.Word] {
+				if strings.EqualFold(e.PartOfSpeech, entry.PartOfSpeech) {
+					senses = append(senses, e)
+				}
+			}
+			if len(senses) == 0 {
+				continue // keep the definitions we have rather than none
+			}
+			for i := range senses {
+				senses[i].DefinitionIndex = int64(i + 1)
+				senses[i].NumDefinitions = int64(len(senses))
+			}
+			dictionary[entry.Word] = senses
+		}
+	}
+}
+
+// sentences maps words to the example sentences their entries carry.
+func sentences(entries []api.Entry) map[string]string {
+	m := make(map[string]string)
+	for _, entry := range entries {
+		if entry.Sentence != "" {
+			m[entry.Word] = entry.Sentence
+		}
+	}
+	return m
+}
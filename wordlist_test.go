@@ -0,0 +1,103 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+
+	"github.com/jharlan-hash/gospell/internal/config"
+	"github.com/jharlan-hash/gospell/internal/definition"
+)
+
+func TestLoadWordlists_ShuffleAcrossLists(t *testing.T) {
+	dir := t.TempDir()
+	lists := map[string]string{
+		"small.txt": "apple\nbanana\n",
+		"large.txt": "carrot\ndate\nelderberry\nfig\ngrape\nbanana\nhoneydew\n",
+		"third.txt": "kiwi\napple\n",
+	}
+	cfg := config.Default()
+	cfg.Shuffle = true
+	for name, content := range lists {
+		path := filepath.Join(dir, name)
+		if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+		cfg.Wordlists = append(cfg.Wordlists, path)
+	}
+
+	loaded, err := loadWordlists(&cfg, definition.Dictionary{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	union := []string{"apple", "banana", "carrot", "date", "elderberry", "fig", "grape", "honeydew", "kiwi"}
+	if loaded.Words.Len() != len(union) {
+		t.Errorf("Len() = %d, want the %d distinct words", loaded.Words.Len(), len(union))
+	}
+	for round := range 20 {
+		got := make([]string, len(union))
+		for i := range got {
+			got[i] = loaded.Words.Next()
+		}
+		if slices.Sort(got); !slices.Equal(got, union) {
+			t.Fatalf("round %d drew %q, want each of %q once", round, got, union)
+		}
+	}
+}
+
+func TestLoadWordlists_EmbeddedKeepsInflections(t *testing.T) {
+	// lemmas only, as a dictionary built from WordNet has them
+	d := definition.Dictionary{}
+	d.Add("hop", "verb", "jump lightly")
+	d.Add("cat", "noun", "a small feline")
+
+	cfg := config.Default()
+	cfg.Shuffle = true
+	loaded, err := loadWordlists(&cfg, d)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []string{"cat", "cats", "hop", "hopped", "hopping", "hops"}
+	got := make([]string, loaded.Words.Len())
+	for i := range got {
+		got[i] = loaded.Words.Next()
+	}
+	if slices.Sort(got); !slices.Equal(got, want) {
+		t.Errorf("practiced %q, want %q: the forms of the defined lemmas", got, want)
+	}
+}
+
+func TestLoadWordlists_DrillWithLemmaDictionary(t *testing.T) {
+	// lemmas only, as a dictionary built from WordNet has them
+	d := definition.Dictionary{}
+	d.Add("hop", "verb", "jump lightly")
+	d.Add("box", "noun", "a container")
+	d.Add("box", "verb", "put into a box")
+
+	cfg := config.Default()
+	cfg.Drill = true
+	loaded, err := loadWordlists(&cfg, d)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	families := map[string][]string{
+		"hop": {"hop", "hops", "hopped", "hopping"},
+		"box": {"box", "boxes", "boxed", "boxing"},
+	}
+	for range 10 {
+		lemma := loaded.Words.Next()
+		want, ok := families[lemma]
+		if !ok {
+			t.Fatalf("drilled %q, want only the families of the defined lemmas", lemma)
+		}
+		got := []string{lemma}
+		for range len(want) - 1 {
+			got = append(got, loaded.Words.Next())
+		}
+		if !slices.Equal(got, want) {
+			t.Errorf("drilled %q, want the family %q", got, want)
+		}
+	}
+}